@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"gorm.io/gorm"
+	"io"
+	"listaPro/internal/dto"
+	"listaPro/internal/markdown"
+	"listaPro/internal/models"
+	"os"
+)
+
+// runExport grava as listas com as tarefas em JSON (formato da API v2) ou
+// em checklists Markdown
+func runExport(db *gorm.DB, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	listID := flags.Uint("list", 0, "exporta só a lista com este ID")
+	format := flags.String("format", "json", "json ou markdown")
+	output := flags.String("output", "", "arquivo de saída (padrão: stdout)")
+	user := flags.String("user", "", "exporta as listas do usuário")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 || (*format != "json" && *format != "markdown") {
+		return errUsage
+	}
+	if *user != "" {
+		return errors.New("as listas ainda não têm dono; exporte todas ou use --list ID")
+	}
+
+	query := db.Preload("Tasks").Order("id")
+	if *listID != 0 {
+		query = query.Where("id = ?", *listID)
+	}
+	var lists []models.TaskList
+	if err := query.Find(&lists).Error; err != nil {
+		return err
+	}
+	if *listID != 0 && len(lists) == 0 {
+		return fmt.Errorf("lista %d não encontrada", *listID)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if *format == "markdown" {
+		return exportMarkdown(w, lists)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(dto.NewTaskLists(lists))
+}
+
+// exportMarkdown escreve um checklist por lista, separados por linha em branco
+func exportMarkdown(w io.Writer, lists []models.TaskList) error {
+	for i, list := range lists {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		checklist := &markdown.Checklist{Title: list.Name, Items: markdown.ItemsFromTasks(list.Tasks)}
+		if err := markdown.Render(w, checklist); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"listaPro/internal/i18n"
+	"net/http"
+)
+
+// ContentType é o media type do envelope de erro (RFC 7807)
+const ContentType = "application/problem+json"
+
+// Códigos estáveis de erro. Clientes devem decidir pelo código, nunca pela mensagem.
+const (
+	CodeInvalidID             = "invalid_id"
+	CodeInvalidBody           = "invalid_body"
+	CodeValidationFailed      = "validation_failed"
+	CodeListNotFound          = "list_not_found"
+	CodeTaskNotFound          = "task_not_found"
+	CodeWebhookNotFound       = "webhook_not_found"
+	CodeRouteNotFound         = "route_not_found"
+	CodeMethodNotAllowed      = "method_not_allowed"
+	CodePreconditionFailed    = "precondition_failed"
+	CodeIdempotencyKeyReused  = "idempotency_key_reused"
+	CodeIdempotencyInProgress = "idempotency_request_in_progress"
+	CodeInvalidPatch          = "invalid_patch"
+	CodePatchTestFailed       = "patch_test_failed"
+	CodeFieldNotAllowed       = "field_not_allowed"
+	CodeUnsupportedMediaType  = "unsupported_media_type"
+	CodeInvalidChecklist      = "invalid_checklist"
+	CodeUnknownImportSource   = "unknown_import_source"
+	CodeInvalidImportFile     = "invalid_import_file"
+	CodeInvalidSyncToken      = "invalid_sync_token"
+	CodePayloadTooLarge       = "payload_too_large"
+	CodeRateLimited           = "rate_limited"
+	CodeInternal              = "internal_error"
+)
+
+// FieldError descreve um problema em um campo específico da requisição
+type FieldError struct {
+	Field string `json:"field"`
+	Code  string `json:"code"`
+}
+
+// Error é um erro de API que o middleware de erros converte em problem+json.
+// Message é a chave do catálogo de mensagens, traduzida no idioma da requisição.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Args    []any
+	Fields  []FieldError
+	// Header são cabeçalhos extras da resposta (ex.: Accept-Patch)
+	Header map[string]string
+	// Err é a causa original, usada apenas em logs
+	Err error
+}
+
+func (e *Error) Error() string {
+	detail := i18n.T(i18n.Default, e.Message, e.Args...)
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, detail, e.Err)
+	}
+	return e.Code + ": " + detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New cria um erro de API. args preenchem os parâmetros da mensagem.
+func New(status int, code, message string, args ...any) *Error {
+	return &Error{Status: status, Code: code, Message: message, Args: args}
+}
+
+// Wrap cria um erro de API guardando a causa
+func Wrap(err error, status int, code, message string, args ...any) *Error {
+	return &Error{Status: status, Code: code, Message: message, Args: args, Err: err}
+}
+
+// WithField acrescenta um erro de campo
+func (e *Error) WithField(field, code string) *Error {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code})
+	return e
+}
+
+// WithHeader acrescenta um cabeçalho à resposta
+func (e *Error) WithHeader(name, value string) *Error {
+	if e.Header == nil {
+		e.Header = make(map[string]string)
+	}
+	e.Header[name] = value
+	return e
+}
+
+// Problem é o corpo application/problem+json
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"requestId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// ProblemFor monta o corpo de resposta de um erro no idioma pedido. Erros
+// que não são *Error viram 500 sem expor detalhes internos.
+func ProblemFor(err error, instance, requestID, lang string) Problem {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = Wrap(err, http.StatusInternalServerError, CodeInternal, "internal_error")
+	}
+
+	return Problem{
+		Type:      TypeURI(apiErr.Code),
+		Title:     http.StatusText(apiErr.Status),
+		Status:    apiErr.Status,
+		Detail:    i18n.T(lang, apiErr.Message, apiErr.Args...),
+		Instance:  instance,
+		Code:      apiErr.Code,
+		RequestID: requestID,
+		Errors:    apiErr.Fields,
+	}
+}
+
+// TypeURI é o identificador do tipo de problema
+func TypeURI(code string) string {
+	return "urn:listapro:problem:" + code
+}
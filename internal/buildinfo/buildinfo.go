@@ -0,0 +1,52 @@
+// Package buildinfo guarda a versão do binário, preenchida no build:
+//
+//	go build -ldflags "-X listaPro/internal/buildinfo.Commit=abc1234 -X listaPro/internal/buildinfo.BuildTime=2026-10-19T12:00:00Z" .
+//
+// Os workflows passam o hash curto do commit, o mesmo da tag da imagem.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Preenchidos com -ldflags -X
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info descreve o binário em execução
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get retorna as informações do build. Sem -ldflags (ex.: go run), usa o
+// commit que o Go grava no binário quando compilado dentro do repositório.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+	if info.Commit == "" {
+		info.Commit = vcsRevision()
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	return info
+}
+
+func vcsRevision() string {
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range build.Settings {
+		if setting.Key == "vcs.revision" && len(setting.Value) >= 7 {
+			return setting.Value[:7]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,191 @@
+package changes
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// field é o campo dos models que guarda o número da última alteração
+const field = "ChangeSeq"
+
+// createdField guarda o número da alteração que criou o registro
+const createdField = "CreatedSeq"
+
+// ErrInvalidToken é retornado quando o token de sincronização não pode ser lido
+var ErrInvalidToken = errors.New("token de sincronização inválido")
+
+// Register instala callbacks no GORM que atribuem um número de sequência
+// monotônico a cada create, update e delete (soft delete) dos models que
+// possuem o campo ChangeSeq.
+//
+// O número vem da linha única de change_counter, incrementada dentro da
+// transação da escrita. O lock dessa linha vai até o commit, então uma
+// transação só recebe um número depois que a anterior confirmou ou
+// desfez: o sync nunca vê o número N+1 antes do N.
+//
+// O preço, aceito de propósito, é que todas as escritas de listas e
+// tarefas, de todas as réplicas, passam em fila por essa linha. A vazão de
+// escrita do serviço fica limitada a cerca de 1 / (duração média de uma
+// transação de escrita): com transações de 5 ms, uns 200 commits por
+// segundo, e mais réplicas não aumentam esse teto. Para caber nele, as
+// transações de escrita não fazem nada além do banco (chamadas externas,
+// como o envio dos webhooks, ficam fora) e terminam logo. Se o teto virar
+// gargalo, a saída é voltar a uma sequence, que não serializa, e fazer o
+// sync parar antes das transações ainda abertas, usando
+// pg_snapshot_xmin(pg_current_snapshot()) como marca d'água.
+func Register(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("changes:create", assignOnSave(true)); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("changes:update", assignOnSave(false)); err != nil {
+		return err
+	}
+	return db.Callback().Delete().Before("gorm:delete").Register("changes:delete", assignOnDelete)
+}
+
+func tracked(db *gorm.DB) bool {
+	return db.Error == nil && db.Statement.Schema != nil && db.Statement.Schema.LookUpField(field) != nil
+}
+
+func next(db *gorm.DB) (int64, error) {
+	var seq int64
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Raw("UPDATE change_counter SET seq = seq + 1 WHERE id = 1 RETURNING seq").
+		Scan(&seq).Error
+	return seq, err
+}
+
+// Lock reserva o contador para a transação tx. Quem trava linhas com
+// SELECT ... FOR UPDATE antes de escrever deve chamá-lo primeiro, para
+// pegar os locks na mesma ordem das demais escritas (contador, depois a
+// linha) e não criar deadlocks.
+func Lock(tx *gorm.DB) error {
+	return tx.Exec("SELECT seq FROM change_counter WHERE id = 1 FOR UPDATE").Error
+}
+
+// assignOnSave numera o registro; no create, o mesmo número vai para o CreatedSeq
+func assignOnSave(create bool) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if !tracked(db) {
+			return
+		}
+
+		stmt := db.Statement
+		fields := []*schema.Field{stmt.Schema.LookUpField(field)}
+		if created := stmt.Schema.LookUpField(createdField); create && created != nil {
+			fields = append(fields, created)
+		}
+
+		if stmt.ReflectValue.Kind() == reflect.Slice || stmt.ReflectValue.Kind() == reflect.Array {
+			// cada registro do lote recebe seu próprio número para que a paginação
+			// por "change_seq > token" nunca corte um grupo de valores iguais
+			for i := 0; i < stmt.ReflectValue.Len(); i++ {
+				seq, err := next(db)
+				if err != nil {
+					db.AddError(err)
+					return
+				}
+				for _, f := range fields {
+					db.AddError(f.Set(stmt.Context, stmt.ReflectValue.Index(i), seq))
+				}
+			}
+			return
+		}
+
+		seq, err := next(db)
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+		for _, f := range fields {
+			stmt.SetColumn(f.Name, seq)
+		}
+	}
+}
+
+func assignOnDelete(db *gorm.DB) {
+	if !tracked(db) || db.Statement.Unscoped {
+		return
+	}
+
+	seq, err := next(db)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	// o soft delete do GORM substitui a expressão do SET por "deleted_at = ?",
+	// mas preserva o AfterExpression, onde acrescentamos a nossa coluna
+	f := db.Statement.Schema.LookUpField(field)
+	if db.Statement.ReflectValue.Kind() == reflect.Struct {
+		// o model carregado fica com o número da exclusão, usado nos eventos
+		db.AddError(f.Set(db.Statement.Context, db.Statement.ReflectValue, seq))
+	}
+	set := db.Statement.Clauses["SET"]
+	set.Name = "SET"
+	set.AfterExpression = clause.Expr{SQL: ", ? = ?", Vars: []any{clause.Column{Name: f.DBName}, seq}}
+	db.Statement.Clauses["SET"] = set
+}
+
+// Token identifica até onde o cliente já sincronizou. Seq é a última
+// alteração entregue; Base é até onde o cliente estava completo antes da
+// paginação começar, e separa os registros criados dos alterados.
+type Token struct {
+	Seq  int64
+	Base int64
+}
+
+// Encode gera a representação opaca do token
+func (t Token) Encode() string {
+	raw := fmt.Sprintf("v2:%d:%d", t.Seq, t.Base)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseToken lê um token gerado por Encode. Token vazio significa "desde o
+// início". Tokens v1 (seq e horário) continuam aceitos, com Base = Seq.
+func ParseToken(s string) (Token, error) {
+	if s == "" {
+		return Token{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Token{}, ErrInvalidToken
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 || (parts[0] != "v1" && parts[0] != "v2") {
+		return Token{}, ErrInvalidToken
+	}
+
+	seq, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || seq < 0 {
+		return Token{}, ErrInvalidToken
+	}
+	other, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return Token{}, ErrInvalidToken
+	}
+	if parts[0] == "v1" {
+		return Token{Seq: seq, Base: seq}, nil
+	}
+	if other < 0 || other > seq {
+		return Token{}, ErrInvalidToken
+	}
+	return Token{Seq: seq, Base: other}, nil
+}
+
+// Created indica se um registro criado na alteração createdSeq é novo para
+// o cliente. No primeiro sync (Base 0) tudo é novo, inclusive os registros
+// anteriores ao created_seq, que ficaram com 0.
+func (t Token) Created(createdSeq int64) bool {
+	return t.Base == 0 || createdSeq > t.Base
+}
@@ -0,0 +1,44 @@
+package changes
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	t.Run("Deve ir e voltar sem perder dados", func(t *testing.T) {
+		token := Token{Seq: 42, Base: 30}
+
+		parsed, err := ParseToken(token.Encode())
+		assert.NoError(t, err)
+		assert.Equal(t, token, parsed)
+	})
+
+	t.Run("Token vazio começa do zero", func(t *testing.T) {
+		parsed, err := ParseToken("")
+		assert.NoError(t, err)
+		assert.Equal(t, Token{}, parsed)
+	})
+
+	t.Run("Aceita tokens v1, sem paginação em andamento", func(t *testing.T) {
+		parsed, err := ParseToken(base64.RawURLEncoding.EncodeToString([]byte("v1:42:1700000000123")))
+		assert.NoError(t, err)
+		assert.Equal(t, Token{Seq: 42, Base: 42}, parsed)
+	})
+
+	t.Run("Deve rejeitar tokens inválidos", func(t *testing.T) {
+		for _, token := range []string{"!!!", "djM6MTox", "djE6LTE6MA", "djI6MTo1"} {
+			_, err := ParseToken(token)
+			assert.ErrorIs(t, err, ErrInvalidToken, token)
+		}
+	})
+}
+
+func TestTokenCreated(t *testing.T) {
+	assert.True(t, Token{}.Created(0), "primeiro sync")
+	assert.True(t, Token{Seq: 50, Base: 10}.Created(20), "criado depois da base, mesmo em outra página")
+	assert.False(t, Token{Seq: 50, Base: 10}.Created(5))
+	assert.False(t, Token{Seq: 50, Base: 10}.Created(0), "registro anterior ao created_seq")
+}
@@ -0,0 +1,484 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"log/slog"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config reúne toda a configuração da aplicação. Load parte dos valores
+// padrão, aplica o arquivo YAML opcional (CONFIG_FILE) e, por cima, as
+// variáveis de ambiente (incluindo as do .env, carregadas no main).
+type Config struct {
+	Env       string          `yaml:"env"`
+	HTTP      HTTPConfig      `yaml:"http"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	Database  DatabaseConfig  `yaml:"database"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Logging   LoggingConfig   `yaml:"logging"`
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+	Features  FeatureFlags    `yaml:"features"`
+}
+
+// HTTPConfig configura o servidor da API REST e GraphQL
+type HTTPConfig struct {
+	Port              string        `yaml:"port"`
+	ReadTimeout       time.Duration `yaml:"readTimeout"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdownTimeout"`
+	// TrustedProxies são os IPs ou CIDRs dos proxies cujo X-Forwarded-For
+	// vale como IP do cliente; sem nenhum, vale só o endereço da conexão
+	TrustedProxies []string `yaml:"trustedProxies"`
+	// AdminPort é a porta interna de /metrics e /debug/vars, que não devem
+	// ficar expostas junto com a API
+	AdminPort string `yaml:"adminPort"`
+}
+
+// GRPCConfig configura o servidor gRPC
+type GRPCConfig struct {
+	Port string `yaml:"port"`
+}
+
+// DatabaseConfig descreve a conexão com o Postgres e o pool de conexões
+type DatabaseConfig struct {
+	Host            string        `yaml:"host"`
+	Port            string        `yaml:"port"`
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	Name            string        `yaml:"name"`
+	SSLMode         string        `yaml:"sslMode"`
+	ConnectTimeout  time.Duration `yaml:"connectTimeout"`
+	MaxOpenConns    int           `yaml:"maxOpenConns"`
+	MaxIdleConns    int           `yaml:"maxIdleConns"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime"`
+	// SlowQueryThreshold é a partir de quando uma consulta vai para o log
+	SlowQueryThreshold time.Duration `yaml:"slowQueryThreshold"`
+}
+
+// CORSConfig é a política de CORS; veja Build
+type CORSConfig struct {
+	AllowOrigins     []string      `yaml:"allowOrigins"`
+	AllowMethods     []string      `yaml:"allowMethods"`
+	AllowHeaders     []string      `yaml:"allowHeaders"`
+	ExposeHeaders    []string      `yaml:"exposeHeaders"`
+	AllowCredentials bool          `yaml:"allowCredentials"`
+	MaxAge           time.Duration `yaml:"maxAge"`
+}
+
+// TracingConfig configura o OpenTelemetry. O destino do exporter otlp vem
+// das variáveis padrão do SDK (OTEL_EXPORTER_OTLP_ENDPOINT e afins).
+type TracingConfig struct {
+	// Exporter é none, otlp ou stdout
+	Exporter    string  `yaml:"exporter"`
+	ServiceName string  `yaml:"serviceName"`
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+// LoggingConfig configura os logs estruturados
+type LoggingConfig struct {
+	// Level é debug, info, warn ou error
+	Level string `yaml:"level"`
+	// Format é json ou text
+	Format string `yaml:"format"`
+}
+
+// RateLimitConfig limita as requisições de cada cliente na API. As
+// escritas, que custam mais ao banco, têm um limite mais apertado.
+type RateLimitConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Read    RateLimitRule `yaml:"read"`
+	Write   RateLimitRule `yaml:"write"`
+}
+
+// RateLimitRule é um token bucket: PerMinute requisições por minuto, com
+// rajadas de até Burst
+type RateLimitRule struct {
+	PerMinute int `yaml:"perMinute"`
+	Burst     int `yaml:"burst"`
+}
+
+// FeatureFlags ligam ou desligam partes do servidor
+type FeatureFlags struct {
+	// MigrateOnStart aplica as migrações ao subir; com false, elas ficam
+	// para um Job separado (listapro migrate up)
+	MigrateOnStart bool `yaml:"migrateOnStart"`
+	// WebhookWorker processa a fila de entregas de webhooks
+	WebhookWorker bool `yaml:"webhookWorker"`
+	// GRPC sobe a API gRPC na porta GRPC.Port
+	GRPC bool `yaml:"grpc"`
+}
+
+// Default retorna a configuração padrão. O ambiente padrão é prod, que
+// exige CORS_ALLOW_ORIGINS: um deploy sem APP_ENV não sobe com a política
+// aberta de dev, que precisa ser pedida com APP_ENV=dev.
+func Default() Config {
+	return Config{
+		Env: EnvProd,
+		HTTP: HTTPConfig{
+			Port:              "8080",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   25 * time.Second,
+			AdminPort:         "9091",
+		},
+		GRPC: GRPCConfig{Port: "9090"},
+		Database: DatabaseConfig{
+			Port:               "5432",
+			SSLMode:            "disable",
+			ConnectTimeout:     time.Minute,
+			MaxOpenConns:       25,
+			MaxIdleConns:       10,
+			ConnMaxLifetime:    30 * time.Minute,
+			ConnMaxIdleTime:    5 * time.Minute,
+			SlowQueryThreshold: 200 * time.Millisecond,
+		},
+		CORS: CORSConfig{
+			AllowMethods:     defaultCORSMethods,
+			AllowHeaders:     defaultCORSHeaders,
+			ExposeHeaders:    defaultCORSExpose,
+			AllowCredentials: true,
+			MaxAge:           12 * time.Hour,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "listapro-backend",
+			SampleRatio: 1,
+		},
+		Logging: LoggingConfig{Level: "info", Format: "json"},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Read:    RateLimitRule{PerMinute: 600, Burst: 100},
+			Write:   RateLimitRule{PerMinute: 120, Burst: 20},
+		},
+		Features: FeatureFlags{
+			MigrateOnStart: true,
+			WebhookWorker:  true,
+			GRPC:           true,
+		},
+	}
+}
+
+// Load monta e valida a configuração. Os erros de todas as variáveis são
+// devolvidos juntos, para que um deploy mal configurado mostre tudo de uma vez.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	env := &envReader{}
+	env.string("APP_ENV", &cfg.Env)
+
+	env.string("PORT", &cfg.HTTP.Port)
+	env.duration("HTTP_READ_TIMEOUT", &cfg.HTTP.ReadTimeout)
+	env.duration("HTTP_READ_HEADER_TIMEOUT", &cfg.HTTP.ReadHeaderTimeout)
+	env.duration("HTTP_WRITE_TIMEOUT", &cfg.HTTP.WriteTimeout)
+	env.duration("HTTP_IDLE_TIMEOUT", &cfg.HTTP.IdleTimeout)
+	env.duration("SHUTDOWN_TIMEOUT", &cfg.HTTP.ShutdownTimeout)
+	env.list("TRUSTED_PROXIES", &cfg.HTTP.TrustedProxies)
+	env.string("ADMIN_PORT", &cfg.HTTP.AdminPort)
+
+	env.string("GRPC_PORT", &cfg.GRPC.Port)
+
+	env.string("DB_HOST", &cfg.Database.Host)
+	env.string("DB_PORT", &cfg.Database.Port)
+	env.string("DB_USER", &cfg.Database.User)
+	env.secret("DB_PASSWORD", &cfg.Database.Password)
+	env.string("DB_NAME", &cfg.Database.Name)
+	env.string("DB_SSLMODE", &cfg.Database.SSLMode)
+	env.duration("DB_CONNECT_TIMEOUT", &cfg.Database.ConnectTimeout)
+	env.int("DB_MAX_OPEN_CONNS", &cfg.Database.MaxOpenConns)
+	env.int("DB_MAX_IDLE_CONNS", &cfg.Database.MaxIdleConns)
+	env.duration("DB_CONN_MAX_LIFETIME", &cfg.Database.ConnMaxLifetime)
+	env.duration("DB_CONN_MAX_IDLE_TIME", &cfg.Database.ConnMaxIdleTime)
+	env.duration("DB_SLOW_QUERY_THRESHOLD", &cfg.Database.SlowQueryThreshold)
+
+	env.list("CORS_ALLOW_ORIGINS", &cfg.CORS.AllowOrigins)
+	env.list("CORS_ALLOW_METHODS", &cfg.CORS.AllowMethods)
+	env.list("CORS_ALLOW_HEADERS", &cfg.CORS.AllowHeaders)
+	env.list("CORS_EXPOSE_HEADERS", &cfg.CORS.ExposeHeaders)
+	env.bool("CORS_ALLOW_CREDENTIALS", &cfg.CORS.AllowCredentials)
+	env.duration("CORS_MAX_AGE", &cfg.CORS.MaxAge)
+
+	env.string("TRACING_EXPORTER", &cfg.Tracing.Exporter)
+	env.string("OTEL_SERVICE_NAME", &cfg.Tracing.ServiceName)
+	env.float("TRACING_SAMPLE_RATIO", &cfg.Tracing.SampleRatio)
+
+	env.string("LOG_LEVEL", &cfg.Logging.Level)
+	env.string("LOG_FORMAT", &cfg.Logging.Format)
+
+	env.bool("RATE_LIMIT_ENABLED", &cfg.RateLimit.Enabled)
+	env.int("RATE_LIMIT_READ_PER_MINUTE", &cfg.RateLimit.Read.PerMinute)
+	env.int("RATE_LIMIT_READ_BURST", &cfg.RateLimit.Read.Burst)
+	env.int("RATE_LIMIT_WRITE_PER_MINUTE", &cfg.RateLimit.Write.PerMinute)
+	env.int("RATE_LIMIT_WRITE_BURST", &cfg.RateLimit.Write.Burst)
+
+	env.bool("MIGRATE_ON_START", &cfg.Features.MigrateOnStart)
+	env.bool("WEBHOOK_WORKER", &cfg.Features.WebhookWorker)
+	env.bool("GRPC_ENABLED", &cfg.Features.GRPC)
+
+	cfg.Env = normalizeEnv(cfg.Env)
+
+	if err := errors.Join(append(env.errs, cfg.Validate())...); err != nil {
+		return nil, fmt.Errorf("configuração inválida:\n%w", err)
+	}
+	return &cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("CONFIG_FILE: %w", err)
+	}
+	defer file.Close()
+
+	// campos desconhecidos costumam ser erros de digitação
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("CONFIG_FILE %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate confere a configuração e devolve todos os problemas encontrados
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Env == EnvDev || c.Env == EnvStage || c.Env == EnvProd,
+		"APP_ENV inválido: %q (use dev, stage ou prod)", c.Env)
+
+	check(isPort(c.HTTP.Port), "PORT inválida: %q", c.HTTP.Port)
+	check(c.HTTP.ReadTimeout >= 0 && c.HTTP.ReadHeaderTimeout >= 0 && c.HTTP.WriteTimeout >= 0 && c.HTTP.IdleTimeout >= 0,
+		"os timeouts HTTP não podem ser negativos")
+	check(c.HTTP.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT deve ser maior que zero")
+	for _, proxy := range c.HTTP.TrustedProxies {
+		check(isIPOrCIDR(proxy), "TRUSTED_PROXIES inválido: %q (use IPs ou CIDRs)", proxy)
+	}
+
+	check(isPort(c.HTTP.AdminPort), "ADMIN_PORT inválida: %q", c.HTTP.AdminPort)
+	check(c.HTTP.AdminPort != c.HTTP.Port, "ADMIN_PORT e PORT não podem ser iguais (%s)", c.HTTP.Port)
+
+	if c.Features.GRPC {
+		check(isPort(c.GRPC.Port), "GRPC_PORT inválida: %q", c.GRPC.Port)
+		check(c.GRPC.Port != c.HTTP.Port, "GRPC_PORT e PORT não podem ser iguais (%s)", c.HTTP.Port)
+		check(c.GRPC.Port != c.HTTP.AdminPort, "GRPC_PORT e ADMIN_PORT não podem ser iguais (%s)", c.GRPC.Port)
+	}
+
+	check(c.Tracing.Exporter == "none" || c.Tracing.Exporter == "otlp" || c.Tracing.Exporter == "stdout",
+		"TRACING_EXPORTER inválido: %q (use none, otlp ou stdout)", c.Tracing.Exporter)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1,
+		"TRACING_SAMPLE_RATIO deve estar entre 0 e 1: %v", c.Tracing.SampleRatio)
+
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Logging.Level)) == nil,
+		"LOG_LEVEL inválido: %q (use debug, info, warn ou error)", c.Logging.Level)
+	check(c.Logging.Format == "json" || c.Logging.Format == "text",
+		"LOG_FORMAT inválido: %q (use json ou text)", c.Logging.Format)
+
+	if c.RateLimit.Enabled {
+		check(c.RateLimit.Read.PerMinute > 0 && c.RateLimit.Read.Burst > 0,
+			"RATE_LIMIT_READ_PER_MINUTE e RATE_LIMIT_READ_BURST devem ser maiores que zero")
+		check(c.RateLimit.Write.PerMinute > 0 && c.RateLimit.Write.Burst > 0,
+			"RATE_LIMIT_WRITE_PER_MINUTE e RATE_LIMIT_WRITE_BURST devem ser maiores que zero")
+	}
+
+	if err := c.Database.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.CORS.Validate(c.Env); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Validate confere os dados de conexão e os limites do pool
+func (d DatabaseConfig) Validate() error {
+	var errs []error
+	for _, required := range [][2]string{{"DB_HOST", d.Host}, {"DB_USER", d.User}, {"DB_NAME", d.Name}} {
+		if required[1] == "" {
+			errs = append(errs, fmt.Errorf("%s é obrigatório", required[0]))
+		}
+	}
+	if !isPort(d.Port) {
+		errs = append(errs, fmt.Errorf("DB_PORT inválida: %q", d.Port))
+	}
+	switch d.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		errs = append(errs, fmt.Errorf("DB_SSLMODE inválido: %q", d.SSLMode))
+	}
+	if d.ConnectTimeout <= 0 {
+		errs = append(errs, errors.New("DB_CONNECT_TIMEOUT deve ser maior que zero"))
+	}
+	if d.MaxOpenConns < 0 || d.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("DB_MAX_OPEN_CONNS e DB_MAX_IDLE_CONNS não podem ser negativos"))
+	} else if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("DB_MAX_IDLE_CONNS (%d) maior que DB_MAX_OPEN_CONNS (%d)", d.MaxIdleConns, d.MaxOpenConns))
+	}
+	if d.ConnMaxLifetime < 0 || d.ConnMaxIdleTime < 0 || d.SlowQueryThreshold < 0 {
+		errs = append(errs, errors.New("DB_CONN_MAX_LIFETIME, DB_CONN_MAX_IDLE_TIME e DB_SLOW_QUERY_THRESHOLD não podem ser negativos"))
+	}
+	return errors.Join(errs...)
+}
+
+// isIPOrCIDR aceita os formatos de gin.Engine.SetTrustedProxies
+func isIPOrCIDR(value string) bool {
+	if _, err := netip.ParsePrefix(value); err == nil {
+		return true
+	}
+	_, err := netip.ParseAddr(value)
+	return err == nil
+}
+
+// DSN monta a string de conexão no formato chave=valor do Postgres
+func (d DatabaseConfig) DSN() string {
+	params := [][2]string{
+		{"host", d.Host},
+		{"port", d.Port},
+		{"user", d.User},
+		{"password", d.Password},
+		{"dbname", d.Name},
+		{"sslmode", d.SSLMode},
+		// cada tentativa desiste logo, para o retry de ConnectDB assumir
+		{"connect_timeout", "5"},
+	}
+	parts := make([]string, 0, len(params))
+	for _, param := range params {
+		if param[1] == "" {
+			continue
+		}
+		parts = append(parts, param[0]+"="+quoteDSN(param[1]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteDSN coloca o valor entre aspas simples quando necessário
+func quoteDSN(value string) string {
+	if !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+// normalizeEnv aceita os nomes longos dos ambientes
+func normalizeEnv(env string) string {
+	switch env = strings.ToLower(strings.TrimSpace(env)); env {
+	case "development":
+		return EnvDev
+	case "staging":
+		return EnvStage
+	case "", "production":
+		return EnvProd
+	default:
+		return env
+	}
+}
+
+// envReader aplica as variáveis definidas sobre a configuração, guardando
+// os erros de conversão
+type envReader struct {
+	errs []error
+}
+
+func (r *envReader) string(name string, dst *string) {
+	if raw, ok := os.LookupEnv(name); ok && strings.TrimSpace(raw) != "" {
+		*dst = strings.TrimSpace(raw)
+	}
+}
+
+// secret lê o valor como está: espaços nas pontas fazem parte de senhas
+func (r *envReader) secret(name string, dst *string) {
+	if raw, ok := os.LookupEnv(name); ok && raw != "" {
+		*dst = raw
+	}
+}
+
+func (r *envReader) int(name string, dst *int) {
+	var raw string
+	if r.string(name, &raw); raw == "" {
+		return
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s deve ser um número inteiro: %q", name, raw))
+		return
+	}
+	*dst = value
+}
+
+func (r *envReader) bool(name string, dst *bool) {
+	var raw string
+	if r.string(name, &raw); raw == "" {
+		return
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s deve ser true ou false: %q", name, raw))
+		return
+	}
+	*dst = value
+}
+
+func (r *envReader) float(name string, dst *float64) {
+	var raw string
+	if r.string(name, &raw); raw == "" {
+		return
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s deve ser um número: %q", name, raw))
+		return
+	}
+	*dst = value
+}
+
+func (r *envReader) duration(name string, dst *time.Duration) {
+	var raw string
+	if r.string(name, &raw); raw == "" {
+		return
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s deve ser uma duração como 30s ou 5m: %q", name, raw))
+		return
+	}
+	*dst = value
+}
+
+func (r *envReader) list(name string, dst *[]string) {
+	var raw string
+	if r.string(name, &raw); raw == "" {
+		return
+	}
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*dst = items
+}
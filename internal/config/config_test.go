@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setRequiredEnv define só o obrigatório, limpando o que vier do ambiente
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"CONFIG_FILE", "APP_ENV", "PORT", "GRPC_PORT", "DB_PORT", "DB_PASSWORD", "DB_SSLMODE",
+		"DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "CORS_ALLOW_ORIGINS", "CORS_ALLOW_CREDENTIALS",
+		"MIGRATE_ON_START", "HTTP_WRITE_TIMEOUT", "TRACING_EXPORTER", "TRACING_SAMPLE_RATIO",
+		"LOG_LEVEL", "LOG_FORMAT", "DB_SLOW_QUERY_THRESHOLD", "RATE_LIMIT_ENABLED", "RATE_LIMIT_WRITE_BURST", "TRUSTED_PROXIES",
+		"ADMIN_PORT",
+	} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_USER", "listapro")
+	t.Setenv("DB_NAME", "listapro")
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Usa os valores padrão", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("CORS_ALLOW_ORIGINS", "https://app.listapro.com.br")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, EnvProd, cfg.Env)
+		assert.Equal(t, "8080", cfg.HTTP.Port)
+		assert.Equal(t, "9090", cfg.GRPC.Port)
+		assert.Equal(t, "9091", cfg.HTTP.AdminPort)
+		assert.Equal(t, "5432", cfg.Database.Port)
+		assert.True(t, cfg.Features.MigrateOnStart)
+	})
+
+	t.Run("Sem APP_ENV exige as origens de CORS", func(t *testing.T) {
+		setRequiredEnv(t)
+
+		_, err := Load()
+		assert.ErrorContains(t, err, "CORS_ALLOW_ORIGINS é obrigatório no ambiente prod")
+	})
+
+	t.Run("Senha do banco não perde os espaços", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("APP_ENV", "dev")
+		t.Setenv("DB_PASSWORD", " s3nha com espaço ")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, " s3nha com espaço ", cfg.Database.Password)
+	})
+
+	t.Run("Variáveis de ambiente sobrescrevem os padrões", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("APP_ENV", "production")
+		t.Setenv("PORT", "3000")
+		t.Setenv("CORS_ALLOW_ORIGINS", "https://app.listapro.com.br")
+		t.Setenv("DB_MAX_OPEN_CONNS", "50")
+		t.Setenv("HTTP_WRITE_TIMEOUT", "1m")
+		t.Setenv("MIGRATE_ON_START", "false")
+		t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 192.168.1.10")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, EnvProd, cfg.Env)
+		assert.Equal(t, "3000", cfg.HTTP.Port)
+		assert.Equal(t, 50, cfg.Database.MaxOpenConns)
+		assert.Equal(t, time.Minute, cfg.HTTP.WriteTimeout)
+		assert.False(t, cfg.Features.MigrateOnStart)
+		assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.10"}, cfg.HTTP.TrustedProxies)
+	})
+
+	t.Run("Lista todos os erros de uma vez", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("DB_HOST", "")
+		t.Setenv("PORT", "http")
+		t.Setenv("DB_MAX_OPEN_CONNS", "muitas")
+		t.Setenv("APP_ENV", "qa")
+		t.Setenv("TRACING_EXPORTER", "jaeger")
+		t.Setenv("TRACING_SAMPLE_RATIO", "2")
+		t.Setenv("LOG_LEVEL", "verbose")
+		t.Setenv("RATE_LIMIT_WRITE_BURST", "0")
+		t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8,ingress")
+		t.Setenv("ADMIN_PORT", "9090")
+
+		_, err := Load()
+		require.Error(t, err)
+		for _, expected := range []string{
+			"DB_HOST é obrigatório", "PORT inválida", "DB_MAX_OPEN_CONNS", "APP_ENV inválido",
+			"TRACING_EXPORTER inválido", "TRACING_SAMPLE_RATIO", "LOG_LEVEL",
+			"RATE_LIMIT_WRITE_BURST", `TRUSTED_PROXIES inválido: "ingress"`,
+			"GRPC_PORT e ADMIN_PORT não podem ser iguais",
+		} {
+			assert.ErrorContains(t, err, expected)
+		}
+	})
+
+	t.Run("Lê o arquivo YAML, com o ambiente por cima", func(t *testing.T) {
+		setRequiredEnv(t)
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+http:
+  port: "8081"
+  shutdownTimeout: 10s
+database:
+  maxIdleConns: 2
+features:
+  grpc: false
+`), 0o600))
+		t.Setenv("CONFIG_FILE", path)
+		t.Setenv("APP_ENV", "dev")
+		t.Setenv("PORT", "8082")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, "8082", cfg.HTTP.Port)
+		assert.Equal(t, 10*time.Second, cfg.HTTP.ShutdownTimeout)
+		assert.Equal(t, 2, cfg.Database.MaxIdleConns)
+		assert.False(t, cfg.Features.GRPC)
+	})
+
+	t.Run("Campo desconhecido no YAML é erro", func(t *testing.T) {
+		setRequiredEnv(t)
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("htpp:\n  port: \"8081\"\n"), 0o600))
+		t.Setenv("CONFIG_FILE", path)
+
+		_, err := Load()
+		assert.ErrorContains(t, err, "htpp")
+	})
+}
+
+func TestDSN(t *testing.T) {
+	cfg := DatabaseConfig{Host: "db", Port: "5432", User: "app", Password: "p4ss 'x'", Name: "listapro", SSLMode: "require"}
+	assert.Equal(t, `host=db port=5432 user=app password='p4ss \'x\'' dbname=listapro sslmode=require connect_timeout=5`, cfg.DSN())
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-contrib/cors"
+	"strconv"
+	"strings"
+)
+
+// Ambientes de implantação, um para cada workflow
+// (dev -> dev, release -> stage, main -> prod)
+const (
+	EnvDev   = "dev"
+	EnvStage = "stage"
+	EnvProd  = "prod"
+)
+
+var (
+	defaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	defaultCORSHeaders = []string{"Origin", "Content-Type", "Authorization", "Accept-Language", "If-Match", "If-None-Match", "Idempotency-Key", "X-Request-ID", "traceparent", "tracestate"}
+	defaultCORSExpose  = []string{"Content-Length", "ETag", "X-Request-ID", "Deprecation", "Link",
+		"Retry-After", "RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"}
+
+	// em dev, qualquer porta local é aceita
+	devCORSOrigins = []string{"http://localhost:*", "http://127.0.0.1:*"}
+)
+
+// Validate confere a política: em stage e prod as origens são obrigatórias,
+// e "*" só é aceito sem credenciais, já que os navegadores recusam essa
+// combinação
+func (c CORSConfig) Validate(env string) error {
+	if len(c.AllowOrigins) == 0 {
+		if env != EnvDev {
+			return fmt.Errorf("CORS_ALLOW_ORIGINS é obrigatório no ambiente %s", env)
+		}
+		return nil
+	}
+	if c.MaxAge < 0 {
+		return errors.New("CORS_MAX_AGE não pode ser negativo")
+	}
+	if c.allowsAll() {
+		if c.AllowCredentials {
+			return errors.New(`CORS_ALLOW_ORIGINS="*" não pode ser usado com CORS_ALLOW_CREDENTIALS=true`)
+		}
+		return nil
+	}
+	_, err := NewOriginMatcher(c.AllowOrigins)
+	return err
+}
+
+// Build monta a configuração do middleware de CORS. Sem origens, em dev,
+// qualquer porta local é aceita.
+func (c CORSConfig) Build(env string) (cors.Config, error) {
+	if err := c.Validate(env); err != nil {
+		return cors.Config{}, err
+	}
+
+	config := cors.Config{
+		AllowMethods:     c.AllowMethods,
+		AllowHeaders:     c.AllowHeaders,
+		ExposeHeaders:    c.ExposeHeaders,
+		AllowCredentials: c.AllowCredentials,
+		MaxAge:           c.MaxAge,
+	}
+	if c.allowsAll() {
+		config.AllowAllOrigins = true
+		return config, nil
+	}
+
+	origins := c.AllowOrigins
+	if len(origins) == 0 {
+		origins = devCORSOrigins
+	}
+	matcher, err := NewOriginMatcher(origins)
+	if err != nil {
+		return cors.Config{}, err
+	}
+	// com uma função, o middleware devolve a própria origem no
+	// Access-Control-Allow-Origin, o que funciona com credenciais
+	config.AllowOriginFunc = matcher.Match
+	return config, nil
+}
+
+func (c CORSConfig) allowsAll() bool {
+	return len(c.AllowOrigins) == 1 && c.AllowOrigins[0] == "*"
+}
+
+// OriginMatcher compara origens com a lista permitida, aceitando um "*"
+// por padrão no lugar de subdomínios (https://*.listapro.app) ou da porta
+// (http://localhost:*)
+type OriginMatcher struct {
+	exact    map[string]bool
+	patterns [][2]string // prefixo e sufixo em volta do "*"
+}
+
+func NewOriginMatcher(origins []string) (*OriginMatcher, error) {
+	m := &OriginMatcher{exact: make(map[string]bool)}
+	for _, origin := range origins {
+		origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+		if !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			return nil, fmt.Errorf("origem CORS inválida: %q (use http:// ou https://)", origin)
+		}
+		switch strings.Count(origin, "*") {
+		case 0:
+			m.exact[origin] = true
+		case 1:
+			prefix, suffix, _ := strings.Cut(origin, "*")
+			m.patterns = append(m.patterns, [2]string{prefix, suffix})
+		default:
+			return nil, fmt.Errorf("origem CORS inválida: %q (apenas um * por origem)", origin)
+		}
+	}
+	return m, nil
+}
+
+// Match informa se a origem é permitida
+func (m *OriginMatcher) Match(origin string) bool {
+	origin = strings.ToLower(origin)
+	if m.exact[origin] {
+		return true
+	}
+	for _, pattern := range m.patterns {
+		prefix, suffix := pattern[0], pattern[1]
+		if len(origin) <= len(prefix)+len(suffix) ||
+			!strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+			continue
+		}
+		middle := origin[len(prefix) : len(origin)-len(suffix)]
+		if strings.HasSuffix(prefix, ":") {
+			// "*" no lugar da porta
+			if isPort(middle) {
+				return true
+			}
+		} else if isHostLabel(middle) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHostLabel aceita apenas o que pode compor um host ou uma porta, para
+// que o "*" não engula barras, arrobas ou outra origem inteira
+func isHostLabel(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	return !strings.HasPrefix(s, ".") && !strings.HasSuffix(s, ".")
+}
+
+func isPort(s string) bool {
+	port, err := strconv.ParseUint(s, 10, 16)
+	return err == nil && port > 0
+}
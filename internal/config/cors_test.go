@@ -0,0 +1,110 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/gin-contrib/cors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOriginMatcher(t *testing.T) {
+	matcher, err := NewOriginMatcher([]string{
+		"https://app.listapro.com.br",
+		"https://*.listapro.app",
+		"http://localhost:*",
+	})
+	require.NoError(t, err)
+
+	allowed := []string{
+		"https://app.listapro.com.br",
+		"https://APP.listapro.com.br",
+		"https://stage.listapro.app",
+		"https://pr-12.preview.listapro.app",
+		"http://localhost:5173",
+	}
+	for _, origin := range allowed {
+		assert.True(t, matcher.Match(origin), origin)
+	}
+
+	denied := []string{
+		"https://listapro.app",
+		"http://stage.listapro.app",
+		"https://evil.com/.listapro.app",
+		"https://evil.com#.listapro.app",
+		"https://user@evil.com.listapro.app.evil.com",
+		"http://localhost:5173.evil.com",
+		"https://app.listapro.com.br.evil.com",
+	}
+	for _, origin := range denied {
+		assert.False(t, matcher.Match(origin), origin)
+	}
+
+	t.Run("Origem sem esquema é inválida", func(t *testing.T) {
+		_, err := NewOriginMatcher([]string{"listapro.app"})
+		assert.Error(t, err)
+	})
+}
+
+func TestCORS(t *testing.T) {
+	setRequiredEnv(t)
+
+	t.Run("Dev aceita localhost com credenciais", func(t *testing.T) {
+		t.Setenv("APP_ENV", "dev")
+		t.Setenv("CORS_ALLOW_ORIGINS", "")
+
+		config, err := loadCORS()
+		require.NoError(t, err)
+		assert.True(t, config.AllowCredentials)
+		assert.Contains(t, config.AllowMethods, "PATCH")
+		assert.Contains(t, config.AllowMethods, "OPTIONS")
+		assert.True(t, config.AllowOriginFunc("http://localhost:3000"))
+		assert.False(t, config.AllowOriginFunc("https://listapro.app"))
+		assert.NoError(t, config.Validate())
+	})
+
+	t.Run("Prod exige as origens", func(t *testing.T) {
+		t.Setenv("APP_ENV", "production")
+		t.Setenv("CORS_ALLOW_ORIGINS", "")
+
+		_, err := loadCORS()
+		assert.ErrorContains(t, err, "CORS_ALLOW_ORIGINS")
+	})
+
+	t.Run("Curinga com credenciais é recusado", func(t *testing.T) {
+		t.Setenv("APP_ENV", "stage")
+		t.Setenv("CORS_ALLOW_ORIGINS", "*")
+		t.Setenv("CORS_ALLOW_CREDENTIALS", "")
+
+		_, err := loadCORS()
+		assert.Error(t, err)
+
+		t.Setenv("CORS_ALLOW_CREDENTIALS", "false")
+		config, err := loadCORS()
+		require.NoError(t, err)
+		assert.True(t, config.AllowAllOrigins)
+		assert.NoError(t, config.Validate())
+	})
+
+	t.Run("Listas configuráveis", func(t *testing.T) {
+		t.Setenv("APP_ENV", "prod")
+		t.Setenv("CORS_ALLOW_ORIGINS", "https://*.listapro.app")
+		t.Setenv("CORS_ALLOW_METHODS", "GET, POST")
+		t.Setenv("CORS_MAX_AGE", "10m")
+
+		config, err := loadCORS()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"GET", "POST"}, config.AllowMethods)
+		assert.Equal(t, "10m0s", config.MaxAge.String())
+		assert.True(t, config.AllowOriginFunc("https://app.listapro.app"))
+	})
+}
+
+// loadCORS carrega a configuração do ambiente e monta a política de CORS
+func loadCORS() (cors.Config, error) {
+	cfg, err := Load()
+	if err != nil {
+		return cors.Config{}, err
+	}
+	return cfg.CORS.Build(cfg.Env)
+}
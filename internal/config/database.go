@@ -1,38 +1,119 @@
 package config
 
 import (
+	"context"
+	"expvar"
 	"fmt"
-	"listaPro/internal/models"
-	"os"
+	"listaPro/internal/logging"
+	"listaPro/internal/migrations"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func ConnectDB() *gorm.DB {
-	dsn := fmt.Sprintf(
-		"host=%s user=%s dbname=%s sslmode=%s password=%s port=%s",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_SSLMODE"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_PORT"),
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// Intervalos entre as tentativas de conexão, dobrando a cada falha
+var (
+	minRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff = 10 * time.Second
+)
+
+// ConnectDB abre a conexão com o Postgres e configura o pool. Se o banco
+// ainda não estiver no ar (ex.: reinício do cluster), tenta de novo com
+// backoff até DB_CONNECT_TIMEOUT, em vez de derrubar o pod na hora.
+func ConnectDB(cfg DatabaseConfig) (*gorm.DB, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+	defer cancel()
+
+	db, err := connectWithRetry(ctx, func() (*gorm.DB, error) {
+		return gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{
+			Logger: logging.NewGormLogger(cfg.SlowQueryThreshold),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return db, nil
+}
+
+func connectWithRetry(ctx context.Context, open func() (*gorm.DB, error)) (*gorm.DB, error) {
+	backoff := minRetryBackoff
+	for attempt := 1; ; attempt++ {
+		db, err := open()
+		if err == nil {
+			if attempt > 1 {
+				slog.Info("conectado ao banco de dados", "attempt", attempt)
+			}
+			return db, nil
+		}
+		// o gorm devolve o pool aberto mesmo quando o ping falha
+		if db != nil {
+			if sqlDB, _ := db.DB(); sqlDB != nil {
+				sqlDB.Close()
+			}
+		}
+
+		// jitter para as réplicas não baterem no banco ao mesmo tempo
+		wait := backoff/2 + rand.N(backoff/2+1)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return nil, fmt.Errorf("falha ao conectar ao banco de dados após %d tentativas: %w", attempt, err)
+		}
+		slog.Warn("banco de dados indisponível", "attempt", attempt, "retry_in", wait.Round(time.Millisecond), logging.Err(err))
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("falha ao conectar ao banco de dados após %d tentativas: %w", attempt, err)
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// Migrate aplica as migrações SQL pendentes (internal/migrations)
+func Migrate(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.New(sqlDB)
 	if err != nil {
-		panic("Falha ao conectar ao banco de dados: " + err.Error())
+		return err
 	}
 
-	return db
+	slog.Info("aplicando migrações")
+	applied, err := migrator.Up(context.Background())
+	for _, migration := range applied {
+		slog.Info("migração aplicada", "version", migration.Version, "name", migration.Name)
+	}
+	return err
 }
 
-func Migrate(db *gorm.DB) {
-	// Executar migrações
-	fmt.Println("Migrating database ...")
-	err := db.AutoMigrate(&models.TaskList{}, &models.Task{})
+var publishPoolStats sync.Once
+
+// PublishPoolStats expõe as estatísticas do pool em /debug/vars (expvar),
+// com o nome "database"
+func PublishPoolStats(db *gorm.DB) error {
+	sqlDB, err := db.DB()
 	if err != nil {
-		panic("Falha ao migrar tabelas: " + err.Error())
+		return err
 	}
+	publishPoolStats.Do(func() {
+		expvar.Publish("database", expvar.Func(func() any {
+			return sqlDB.Stats()
+		}))
+	})
+	return nil
 }
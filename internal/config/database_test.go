@@ -0,0 +1,47 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestConnectWithRetry(t *testing.T) {
+	minRetryBackoff, maxRetryBackoff = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() { minRetryBackoff, maxRetryBackoff = 500*time.Millisecond, 10*time.Second })
+
+	t.Run("Tenta de novo até o banco subir", func(t *testing.T) {
+		attempts := 0
+		db, err := connectWithRetry(context.Background(), func() (*gorm.DB, error) {
+			attempts++
+			if attempts < 4 {
+				return nil, errors.New("connection refused")
+			}
+			return &gorm.DB{}, nil
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, db)
+		assert.Equal(t, 4, attempts)
+	})
+
+	t.Run("Desiste no prazo com o último erro", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		attempts := 0
+		started := time.Now()
+		_, err := connectWithRetry(ctx, func() (*gorm.DB, error) {
+			attempts++
+			return nil, errors.New("connection refused")
+		})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "connection refused")
+		assert.Greater(t, attempts, 1)
+		assert.Less(t, time.Since(started), time.Second)
+	})
+}
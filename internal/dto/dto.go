@@ -0,0 +1,219 @@
+// Package dto define o formato das respostas da API v2: nomes em camelCase
+// e sem os campos internos do gorm.Model (DeletedAt).
+package dto
+
+import (
+	"github.com/gin-gonic/gin"
+	"listaPro/internal/models"
+	"strings"
+	"time"
+)
+
+type TaskList struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Tasks     []Task    `json:"tasks,omitempty"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Task struct {
+	ID          uint      `json:"id"`
+	ListID      uint      `json:"listId"`
+	ParentID    *uint     `json:"parentId"`
+	Text        string    `json:"text"`
+	IsCompleted bool      `json:"isCompleted"`
+	Version     int64     `json:"version"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type Webhook struct {
+	ID        uint      `json:"id"`
+	URL       string    `json:"url"`
+	ListID    *uint     `json:"listId"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type WebhookDelivery struct {
+	ID             uint       `json:"id"`
+	WebhookID      uint       `json:"webhookId"`
+	EventType      string     `json:"eventType"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"nextAttemptAt"`
+	ResponseStatus int        `json:"responseStatus"`
+	LastError      string     `json:"lastError"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type WebhookDeadLetter struct {
+	ID         uint      `json:"id"`
+	WebhookID  uint      `json:"webhookId"`
+	DeliveryID uint      `json:"deliveryId"`
+	EventType  string    `json:"eventType"`
+	Payload    string    `json:"payload"`
+	Attempts   int       `json:"attempts"`
+	LastError  string    `json:"lastError"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func NewTaskList(list *models.TaskList) TaskList {
+	return TaskList{
+		ID:        list.ID,
+		Name:      list.Name,
+		Tasks:     NewTasks(list.Tasks),
+		Version:   list.ChangeSeq,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+}
+
+func NewTaskLists(lists []models.TaskList) []TaskList {
+	if lists == nil {
+		return nil
+	}
+	out := make([]TaskList, len(lists))
+	for i := range lists {
+		out[i] = NewTaskList(&lists[i])
+	}
+	return out
+}
+
+func NewTask(task *models.Task) Task {
+	return Task{
+		ID:          task.ID,
+		ListID:      task.ListID,
+		ParentID:    task.ParentID,
+		Text:        task.Text,
+		IsCompleted: task.IsCompleted,
+		Version:     task.ChangeSeq,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+	}
+}
+
+func NewTasks(tasks []models.Task) []Task {
+	if tasks == nil {
+		return nil
+	}
+	out := make([]Task, len(tasks))
+	for i := range tasks {
+		out[i] = NewTask(&tasks[i])
+	}
+	return out
+}
+
+func NewWebhook(hook *models.Webhook) Webhook {
+	return Webhook{
+		ID:        hook.ID,
+		URL:       hook.URL,
+		ListID:    hook.ListID,
+		Events:    splitEvents(hook.Events),
+		Active:    hook.Active,
+		CreatedAt: hook.CreatedAt,
+		UpdatedAt: hook.UpdatedAt,
+	}
+}
+
+func NewWebhookDelivery(delivery *models.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+func NewWebhookDeadLetter(letter *models.WebhookDeadLetter) WebhookDeadLetter {
+	return WebhookDeadLetter{
+		ID:         letter.ID,
+		WebhookID:  letter.WebhookID,
+		DeliveryID: letter.DeliveryID,
+		EventType:  letter.EventType,
+		Payload:    letter.Payload,
+		Attempts:   letter.Attempts,
+		LastError:  letter.LastError,
+		CreatedAt:  letter.CreatedAt,
+	}
+}
+
+// From converte os models (e slices e gin.H que os contenham) para o
+// formato v2. Valores de outros tipos são devolvidos sem alteração.
+func From(value any) any {
+	switch v := value.(type) {
+	case models.TaskList:
+		return NewTaskList(&v)
+	case *models.TaskList:
+		return NewTaskList(v)
+	case []models.TaskList:
+		return nonNil(NewTaskLists(v))
+	case models.Task:
+		return NewTask(&v)
+	case *models.Task:
+		return NewTask(v)
+	case []models.Task:
+		return nonNil(NewTasks(v))
+	case models.Webhook:
+		return NewWebhook(&v)
+	case *models.Webhook:
+		return NewWebhook(v)
+	case []models.Webhook:
+		out := make([]Webhook, len(v))
+		for i := range v {
+			out[i] = NewWebhook(&v[i])
+		}
+		return out
+	case []models.WebhookDelivery:
+		out := make([]WebhookDelivery, len(v))
+		for i := range v {
+			out[i] = NewWebhookDelivery(&v[i])
+		}
+		return out
+	case []models.WebhookDeadLetter:
+		out := make([]WebhookDeadLetter, len(v))
+		for i := range v {
+			out[i] = NewWebhookDeadLetter(&v[i])
+		}
+		return out
+	case gin.H:
+		out := make(gin.H, len(v))
+		for key, item := range v {
+			out[key] = From(item)
+		}
+		return out
+	}
+	return value
+}
+
+// splitEvents separa os tipos de evento gravados separados por vírgula
+func splitEvents(raw string) []string {
+	events := []string{}
+	for _, event := range strings.Split(raw, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// nonNil faz listas vazias saírem como [] em vez de null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
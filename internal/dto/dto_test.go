@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"listaPro/internal/models"
+	"testing"
+)
+
+func TestFrom(t *testing.T) {
+	parentID := uint(3)
+	list := models.TaskList{
+		Model:     gorm.Model{ID: 1},
+		Name:      "Mercado",
+		ChangeSeq: 7,
+		Tasks: []models.Task{
+			{Model: gorm.Model{ID: 4}, Text: "Leite", ListID: 1, ParentID: &parentID, IsCompleted: true, ChangeSeq: 8},
+		},
+	}
+
+	t.Run("Deve usar camelCase sem DeletedAt", func(t *testing.T) {
+		body, err := json.Marshal(From(list))
+		assert.NoError(t, err)
+
+		var fields map[string]any
+		assert.NoError(t, json.Unmarshal(body, &fields))
+		assert.Equal(t, float64(1), fields["id"])
+		assert.Equal(t, "Mercado", fields["name"])
+		assert.Equal(t, float64(7), fields["version"])
+		assert.NotContains(t, fields, "DeletedAt")
+		assert.NotContains(t, fields, "deletedAt")
+
+		task := fields["tasks"].([]any)[0].(map[string]any)
+		assert.Equal(t, float64(1), task["listId"])
+		assert.Equal(t, float64(3), task["parentId"])
+		assert.Equal(t, true, task["isCompleted"])
+	})
+
+	t.Run("Deve converter os valores de gin.H", func(t *testing.T) {
+		converted := From(gin.H{"lists": []models.TaskList{list}, "skipped": 2}).(gin.H)
+		assert.IsType(t, []TaskList{}, converted["lists"])
+		assert.Equal(t, 2, converted["skipped"])
+	})
+
+	t.Run("Listas vazias viram []", func(t *testing.T) {
+		body, _ := json.Marshal(From([]models.Task(nil)))
+		assert.Equal(t, "[]", string(body))
+	})
+
+	t.Run("Eventos do webhook viram array", func(t *testing.T) {
+		hook := From(&models.Webhook{URL: "https://exemplo.com", Events: "task.created, task.updated"}).(Webhook)
+		assert.Equal(t, []string{"task.created", "task.updated"}, hook.Events)
+		assert.Equal(t, []string{}, From(models.Webhook{}).(Webhook).Events)
+	})
+
+	t.Run("Outros tipos não mudam", func(t *testing.T) {
+		assert.Equal(t, "texto", From("texto"))
+	})
+}
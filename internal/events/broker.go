@@ -0,0 +1,155 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Tipos de evento publicados após cada escrita bem-sucedida
+const (
+	ListCreated = "list.created"
+	ListUpdated = "list.updated"
+	ListDeleted = "list.deleted"
+	TaskCreated = "task.created"
+	TaskUpdated = "task.updated"
+	TaskDeleted = "task.deleted"
+)
+
+// Types lista todos os tipos de evento conhecidos
+var Types = []string{ListCreated, ListUpdated, ListDeleted, TaskCreated, TaskUpdated, TaskDeleted}
+
+// Event é uma alteração em uma lista ou tarefa
+type Event struct {
+	ID     uint64    `json:"id"`
+	Type   string    `json:"type"`
+	ListID uint      `json:"listId"`
+	Data   any       `json:"data,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// Subscription recebe os eventos das listas assinadas
+type Subscription struct {
+	C      <-chan Event
+	ch     chan Event
+	lists  map[uint]bool
+	stream bool
+	broker *Broker
+}
+
+// Close cancela a assinatura
+func (s *Subscription) Close() {
+	s.broker.unsubscribe(s)
+}
+
+// Broker distribui eventos para os assinantes em memória
+type Broker struct {
+	mu     sync.Mutex
+	seq    uint64
+	subs   map[*Subscription]struct{}
+	buffer int
+	// closed indica que CloseStreams já foi chamado
+	closed bool
+}
+
+func NewBroker(buffer int) *Broker {
+	return &Broker{subs: make(map[*Subscription]struct{}), buffer: buffer}
+}
+
+// Subscribe assina os eventos das listas informadas; sem listas, assina todas
+func (b *Broker) Subscribe(listIDs ...uint) *Subscription {
+	return b.subscribe(false, listIDs)
+}
+
+// SubscribeStream assina como Subscribe, para conexões de longa duração
+// (SSE, gRPC) que CloseStreams encerra no desligamento do servidor
+func (b *Broker) SubscribeStream(listIDs ...uint) *Subscription {
+	return b.subscribe(true, listIDs)
+}
+
+// CloseStreams fecha as assinaturas de SSE e gRPC, para que as conexões
+// terminem sem esperar o prazo do desligamento. Novas assinaturas de
+// stream já nascem fechadas. As demais continuam recebendo os eventos das
+// requisições ainda em andamento.
+func (b *Broker) CloseStreams() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subs {
+		if sub.stream {
+			delete(b.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+func (b *Broker) subscribe(stream bool, listIDs []uint) *Subscription {
+	ch := make(chan Event, b.buffer)
+	sub := &Subscription{C: ch, ch: ch, stream: stream, broker: b}
+	if len(listIDs) > 0 {
+		sub.lists = make(map[uint]bool, len(listIDs))
+		for _, id := range listIDs {
+			sub.lists[id] = true
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if stream && b.closed {
+		close(ch)
+		return sub
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+func (b *Broker) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Publish envia o evento aos assinantes. Assinantes lentos, com o buffer
+// cheio, perdem o evento em vez de bloquear quem publica.
+func (b *Broker) Publish(eventType string, listID uint, data any) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	event := Event{ID: b.seq, Type: eventType, ListID: listID, Data: data, Time: time.Now().UTC()}
+	for sub := range b.subs {
+		if sub.lists != nil && !sub.lists[listID] {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+	return event
+}
+
+// Default é o broker usado pelos handlers
+var Default = NewBroker(64)
+
+// Publish publica no broker padrão
+func Publish(eventType string, listID uint, data any) Event {
+	return Default.Publish(eventType, listID, data)
+}
+
+// Subscribe assina o broker padrão
+func Subscribe(listIDs ...uint) *Subscription {
+	return Default.Subscribe(listIDs...)
+}
+
+// SubscribeStream assina o broker padrão para uma conexão de longa duração
+func SubscribeStream(listIDs ...uint) *Subscription {
+	return Default.SubscribeStream(listIDs...)
+}
+
+// CloseStreams encerra as assinaturas de stream do broker padrão
+func CloseStreams() {
+	Default.CloseStreams()
+}
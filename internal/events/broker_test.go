@@ -0,0 +1,70 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	t.Run("Deve entregar apenas eventos das listas assinadas", func(t *testing.T) {
+		broker := NewBroker(4)
+		sub := broker.Subscribe(1)
+		all := broker.Subscribe()
+		defer sub.Close()
+		defer all.Close()
+
+		broker.Publish(TaskCreated, 2, nil)
+		broker.Publish(TaskUpdated, 1, "dados")
+
+		event := <-sub.C
+		assert.Equal(t, TaskUpdated, event.Type)
+		assert.Equal(t, uint(1), event.ListID)
+		assert.Equal(t, "dados", event.Data)
+		assert.Len(t, sub.C, 0)
+
+		assert.Len(t, all.C, 2)
+	})
+
+	t.Run("Não deve bloquear com assinante lento", func(t *testing.T) {
+		broker := NewBroker(1)
+		sub := broker.Subscribe()
+		defer sub.Close()
+
+		first := broker.Publish(ListCreated, 1, nil)
+		broker.Publish(ListCreated, 2, nil)
+
+		assert.Equal(t, first.ID, (<-sub.C).ID)
+	})
+
+	t.Run("Close deve fechar o canal", func(t *testing.T) {
+		broker := NewBroker(1)
+		sub := broker.Subscribe()
+		sub.Close()
+		sub.Close()
+
+		_, ok := <-sub.C
+		assert.False(t, ok)
+	})
+
+	t.Run("CloseStreams deve fechar só as assinaturas de stream", func(t *testing.T) {
+		broker := NewBroker(1)
+		stream := broker.SubscribeStream(1)
+		worker := broker.Subscribe()
+		defer worker.Close()
+
+		broker.CloseStreams()
+		stream.Close()
+
+		_, ok := <-stream.C
+		assert.False(t, ok)
+
+		broker.Publish(TaskCreated, 1, nil)
+		assert.Len(t, worker.C, 1)
+
+		late := broker.SubscribeStream()
+		_, ok = <-late.C
+		assert.False(t, ok)
+		late.Close()
+	})
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"context"
+	"listaPro/internal/apierror"
+	"listaPro/internal/i18n"
+	"listaPro/internal/logging"
+	"net/http"
+)
+
+type contextKey struct{}
+
+// requestInfo carrega para os resolvers o que o middleware guardou no gin.Context
+type requestInfo struct {
+	language  string
+	requestID string
+}
+
+func withRequestInfo(ctx context.Context, info requestInfo) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+func getRequestInfo(ctx context.Context) requestInfo {
+	if info, ok := ctx.Value(contextKey{}).(requestInfo); ok {
+		return info
+	}
+	return requestInfo{language: i18n.Default}
+}
+
+// resolverError leva o código estável do apierror para extensions, já que
+// em GraphQL os erros vão no corpo e não no status HTTP
+type resolverError struct {
+	err  *apierror.Error
+	info requestInfo
+}
+
+func (e *resolverError) Error() string {
+	return i18n.T(e.info.language, e.err.Message, e.err.Args...)
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{
+		"code":   e.err.Code,
+		"status": e.err.Status,
+	}
+	if e.info.requestID != "" {
+		ext["requestId"] = e.info.requestID
+	}
+	if len(e.err.Fields) > 0 {
+		ext["errors"] = e.err.Fields
+	}
+	return ext
+}
+
+func fail(ctx context.Context, err *apierror.Error) error {
+	info := getRequestInfo(ctx)
+	if err.Status >= http.StatusInternalServerError {
+		logging.FromContext(ctx).Error("erro em graphql", logging.Err(err))
+	}
+	return &resolverError{err: err, info: info}
+}
@@ -0,0 +1,223 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"listaPro/internal/middleware"
+	"listaPro/internal/models"
+	"listaPro/internal/repositories"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// fakeStore guarda listas e tarefas em memória e conta as consultas
+type fakeStore struct {
+	mu          sync.Mutex
+	lists       []models.TaskList
+	tasks       []models.Task
+	listFinds   int
+	taskBatches int
+}
+
+func (s *fakeStore) Find(_ context.Context, ids ...uint) ([]models.TaskList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listFinds++
+	var found []models.TaskList
+	for _, list := range s.lists {
+		if len(ids) == 0 || containsID(ids, list.ID) {
+			found = append(found, list)
+		}
+	}
+	return found, nil
+}
+
+func (s *fakeStore) Create(_ context.Context, list *models.TaskList) error {
+	list.ID = uint(len(s.lists) + 1)
+	s.lists = append(s.lists, *list)
+	return nil
+}
+
+func (s *fakeStore) Update(_ context.Context, list *models.TaskList) error { return nil }
+func (s *fakeStore) Delete(_ context.Context, list *models.TaskList) error { return nil }
+
+type fakeTaskStore struct{ *fakeStore }
+
+func (s fakeTaskStore) GetByID(_ context.Context, id uint) (*models.Task, error) {
+	for _, task := range s.tasks {
+		if task.ID == id {
+			return &task, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s fakeTaskStore) GetAllByLists(_ context.Context, listIDs []uint) ([]models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.taskBatches++
+	var found []models.Task
+	for _, task := range s.tasks {
+		if containsID(listIDs, task.ListID) {
+			found = append(found, task)
+		}
+	}
+	return found, nil
+}
+
+func (s fakeTaskStore) Search(_ context.Context, filter repositories.TaskFilter) ([]models.Task, error) {
+	var found []models.Task
+	for _, task := range s.tasks {
+		if filter.IsCompleted != nil && task.IsCompleted != *filter.IsCompleted {
+			continue
+		}
+		found = append(found, task)
+	}
+	return found, nil
+}
+
+func (s fakeTaskStore) Create(_ context.Context, task *models.Task) error { return nil }
+func (s fakeTaskStore) Update(_ context.Context, task *models.Task, fields map[string]interface{}) error {
+	return nil
+}
+func (s fakeTaskStore) Delete(_ context.Context, task *models.Task) error { return nil }
+
+func containsID(ids []uint, id uint) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func setupGraphRouter() (*gin.Engine, *fakeStore) {
+	gin.SetMode(gin.TestMode)
+	store := &fakeStore{
+		lists: []models.TaskList{
+			{Model: gorm.Model{ID: 1}, Name: "Mercado"},
+			{Model: gorm.Model{ID: 2}, Name: "Trabalho"},
+			{Model: gorm.Model{ID: 3}, Name: "Casa"},
+		},
+		tasks: []models.Task{
+			{Model: gorm.Model{ID: 1}, Text: "Leite", ListID: 1},
+			{Model: gorm.Model{ID: 2}, Text: "Pão", ListID: 1, IsCompleted: true},
+			{Model: gorm.Model{ID: 3}, Text: "Relatório", ListID: 2},
+			{Model: gorm.Model{ID: 4}, Text: "Lavar louça", ListID: 3, IsCompleted: true},
+		},
+	}
+
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Language(), middleware.Errors())
+	router.POST("/graphql", Handler(NewSchema(NewResolver(store, fakeTaskStore{store}))))
+	return router, store
+}
+
+type graphResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func execute(t *testing.T, router *gin.Engine, query string, header map[string]string) graphResponse {
+	body, _ := json.Marshal(map[string]string{"query": query})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response graphResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func TestListsWithTasks(t *testing.T) {
+	t.Run("Deve buscar as tarefas de todas as listas em uma consulta", func(t *testing.T) {
+		router, store := setupGraphRouter()
+		response := execute(t, router, `{ lists { id name tasks { text list { name } } } }`, nil)
+		assert.Empty(t, response.Errors)
+
+		var lists []struct {
+			ID    string
+			Name  string
+			Tasks []struct {
+				Text string
+				List struct{ Name string }
+			}
+		}
+		assert.NoError(t, json.Unmarshal(response.Data["lists"], &lists))
+		assert.Len(t, lists, 3)
+		assert.Equal(t, "1", lists[0].ID)
+		assert.Len(t, lists[0].Tasks, 2)
+		assert.Equal(t, "Mercado", lists[0].Tasks[0].List.Name)
+
+		assert.Equal(t, 1, store.listFinds)
+		assert.Equal(t, 1, store.taskBatches)
+	})
+
+	t.Run("Deve agrupar as listas das tarefas filtradas", func(t *testing.T) {
+		router, store := setupGraphRouter()
+		response := execute(t, router, `{ tasks(filter: {isCompleted: true}) { text list { name tasks { id } } } }`, nil)
+		assert.Empty(t, response.Errors)
+
+		var tasks []struct {
+			Text string
+			List struct {
+				Name  string
+				Tasks []struct{ ID string }
+			}
+		}
+		assert.NoError(t, json.Unmarshal(response.Data["tasks"], &tasks))
+		assert.Len(t, tasks, 2)
+		assert.Equal(t, "Casa", tasks[1].List.Name)
+
+		assert.Equal(t, 1, store.listFinds)
+		assert.Equal(t, 1, store.taskBatches)
+	})
+}
+
+func TestMutations(t *testing.T) {
+	t.Run("Deve criar uma lista", func(t *testing.T) {
+		router, _ := setupGraphRouter()
+		response := execute(t, router, `mutation { createList(name: "Viagem") { id name tasks { id } } }`, nil)
+		assert.Empty(t, response.Errors)
+		assert.JSONEq(t, `{"id":"4","name":"Viagem","tasks":[]}`, string(response.Data["createList"]))
+	})
+
+	t.Run("Deve devolver o código do erro nas extensions", func(t *testing.T) {
+		router, _ := setupGraphRouter()
+		response := execute(t, router, `mutation { createList(name: "") { id } }`, map[string]string{"Accept-Language": "en"})
+		assert.Len(t, response.Errors, 1)
+		assert.Equal(t, "The name field is required", response.Errors[0].Message)
+		assert.Equal(t, "validation_failed", response.Errors[0].Extensions["code"])
+	})
+
+	t.Run("Tarefa inexistente", func(t *testing.T) {
+		router, _ := setupGraphRouter()
+		response := execute(t, router, `mutation { deleteTask(id: "99") }`, nil)
+		assert.Len(t, response.Errors, 1)
+		assert.Equal(t, "task_not_found", response.Errors[0].Extensions["code"])
+	})
+}
+
+func TestHandlerInvalidBody(t *testing.T) {
+	router, _ := setupGraphRouter()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString("{"))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_body")
+}
@@ -0,0 +1,34 @@
+package graph
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	"listaPro/internal/apierror"
+	"listaPro/internal/middleware"
+	"net/http"
+)
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler (POST /graphql) executa a query do corpo contra o schema
+func Handler(schema *graphql.Schema) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req request
+		if err := c.ShouldBindJSON(&req); err != nil || req.Query == "" {
+			_ = c.Error(apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
+			c.Abort()
+			return
+		}
+
+		ctx := withRequestInfo(c.Request.Context(), requestInfo{
+			language:  middleware.GetLanguage(c),
+			requestID: middleware.GetRequestID(c),
+		})
+		response := schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+		c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,32 @@
+package graph
+
+import (
+	"context"
+	"sync"
+)
+
+// batch carrega de uma só vez os valores de todas as chaves resolvidas
+// juntas. Os resolvers irmãos compartilham o mesmo batch, então
+// lists { tasks } faz uma consulta para as listas e outra para as tarefas,
+// em vez de uma consulta de tarefas por lista.
+type batch[K comparable, V any] struct {
+	keys   []K
+	fetch  func(ctx context.Context, keys []K) (map[K]V, error)
+	once   sync.Once
+	values map[K]V
+	err    error
+}
+
+func newBatch[K comparable, V any](keys []K, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *batch[K, V] {
+	return &batch[K, V]{keys: keys, fetch: fetch}
+}
+
+// load devolve o valor da chave, buscando todas as chaves na primeira chamada.
+// Os irmãos vêm da mesma requisição, então o contexto da primeira chamada vale
+// para todos.
+func (b *batch[K, V]) load(ctx context.Context, key K) (V, error) {
+	b.once.Do(func() {
+		b.values, b.err = b.fetch(ctx, b.keys)
+	})
+	return b.values[key], b.err
+}
@@ -0,0 +1,311 @@
+// Package graph expõe listas e tarefas em GraphQL (/graphql), usando os
+// mesmos repositórios e eventos dos handlers REST.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"github.com/graph-gophers/graphql-go"
+	"gorm.io/gorm"
+	"listaPro/internal/apierror"
+	"listaPro/internal/events"
+	"listaPro/internal/models"
+	"listaPro/internal/repositories"
+	"net/http"
+	"strconv"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Resolver é a raiz das queries e mutations
+type Resolver struct {
+	lists repositories.ListStore
+	tasks repositories.TaskStore
+}
+
+func NewResolver(lists repositories.ListStore, tasks repositories.TaskStore) *Resolver {
+	return &Resolver{lists: lists, tasks: tasks}
+}
+
+// NewSchema interpreta o schema GraphQL com os resolvers informados
+func NewSchema(r *Resolver) *graphql.Schema {
+	return graphql.MustParseSchema(schemaSDL, r, graphql.MaxDepth(10))
+}
+
+// Queries
+
+func (r *Resolver) Lists(ctx context.Context) ([]*listResolver, error) {
+	lists, err := r.lists.Find(ctx)
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_lists_failed"))
+	}
+	return r.newLists(lists), nil
+}
+
+func (r *Resolver) List(ctx context.Context, args struct{ ID graphql.ID }) (*listResolver, error) {
+	id, err := parseID(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := r.lists.Find(ctx, id)
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_list_failed"))
+	}
+	if len(lists) == 0 {
+		return nil, nil
+	}
+	return r.newLists(lists)[0], nil
+}
+
+type taskFilterInput struct {
+	ListID      *graphql.ID
+	IsCompleted *bool
+	Text        *string
+}
+
+func (r *Resolver) Tasks(ctx context.Context, args struct{ Filter *taskFilterInput }) ([]*taskResolver, error) {
+	var filter repositories.TaskFilter
+	if args.Filter != nil {
+		if args.Filter.ListID != nil {
+			listID, err := parseID(ctx, *args.Filter.ListID)
+			if err != nil {
+				return nil, err
+			}
+			filter.ListID = &listID
+		}
+		filter.IsCompleted = args.Filter.IsCompleted
+		if args.Filter.Text != nil {
+			filter.Text = *args.Filter.Text
+		}
+	}
+
+	tasks, err := r.tasks.Search(ctx, filter)
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_tasks_failed"))
+	}
+	return r.newTasks(tasks, nil), nil
+}
+
+// Mutations
+
+func (r *Resolver) CreateList(ctx context.Context, args struct{ Name string }) (*listResolver, error) {
+	if args.Name == "" {
+		return nil, fail(ctx, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "name").WithField("name", "required"))
+	}
+
+	list := models.TaskList{Name: args.Name}
+	if err := r.lists.Create(ctx, &list); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "create_list_failed"))
+	}
+
+	events.Publish(events.ListCreated, list.ID, list)
+	return r.newLists([]models.TaskList{list})[0], nil
+}
+
+func (r *Resolver) UpdateList(ctx context.Context, args struct {
+	ID   graphql.ID
+	Name string
+}) (*listResolver, error) {
+	if args.Name == "" {
+		return nil, fail(ctx, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "name").WithField("name", "required"))
+	}
+	list, err := r.findList(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	list.Name = args.Name
+	if err := r.lists.Update(ctx, list); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "update_list_failed"))
+	}
+
+	events.Publish(events.ListUpdated, list.ID, list)
+	return r.newLists([]models.TaskList{*list})[0], nil
+}
+
+func (r *Resolver) DeleteList(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	list, err := r.findList(ctx, args.ID)
+	if err != nil {
+		return "", err
+	}
+	if err := r.lists.Delete(ctx, list); err != nil {
+		return "", fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "delete_list_failed"))
+	}
+
+	events.Publish(events.ListDeleted, list.ID, nil)
+	return args.ID, nil
+}
+
+func (r *Resolver) CreateTask(ctx context.Context, args struct {
+	ListID graphql.ID
+	Text   string
+}) (*taskResolver, error) {
+	if args.Text == "" {
+		return nil, fail(ctx, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "text").WithField("text", "required"))
+	}
+	list, err := r.findList(ctx, args.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	task := models.Task{Text: args.Text, ListID: list.ID}
+	if err := r.tasks.Create(ctx, &task); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "create_task_failed"))
+	}
+
+	events.Publish(events.TaskCreated, task.ListID, task)
+	return r.newTasks([]models.Task{task}, nil)[0], nil
+}
+
+func (r *Resolver) UpdateTask(ctx context.Context, args struct {
+	ID          graphql.ID
+	Text        *string
+	IsCompleted *bool
+}) (*taskResolver, error) {
+	if args.Text != nil && *args.Text == "" {
+		return nil, fail(ctx, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "text").WithField("text", "required"))
+	}
+	task, err := r.findTask(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if args.Text != nil {
+		fields["text"] = *args.Text
+	}
+	if args.IsCompleted != nil {
+		fields["is_completed"] = *args.IsCompleted
+	}
+	if err := r.tasks.Update(ctx, task, fields); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "update_task_failed"))
+	}
+
+	events.Publish(events.TaskUpdated, task.ListID, task)
+	return r.newTasks([]models.Task{*task}, nil)[0], nil
+}
+
+func (r *Resolver) DeleteTask(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	task, err := r.findTask(ctx, args.ID)
+	if err != nil {
+		return "", err
+	}
+	if err := r.tasks.Delete(ctx, task); err != nil {
+		return "", fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "delete_task_failed"))
+	}
+
+	events.Publish(events.TaskDeleted, task.ListID, map[string]uint{"ID": task.ID})
+	return args.ID, nil
+}
+
+func (r *Resolver) findList(ctx context.Context, rawID graphql.ID) (*models.TaskList, error) {
+	id, err := parseID(ctx, rawID)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := r.lists.Find(ctx, id)
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_list_failed"))
+	}
+	if len(lists) == 0 {
+		return nil, fail(ctx, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
+	}
+	return &lists[0], nil
+}
+
+func (r *Resolver) findTask(ctx context.Context, rawID graphql.ID) (*models.Task, error) {
+	id, err := parseID(ctx, rawID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := r.tasks.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fail(ctx, apierror.New(http.StatusNotFound, apierror.CodeTaskNotFound, "task_not_found"))
+	}
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_task_failed"))
+	}
+	return task, nil
+}
+
+// newLists cria os resolvers das listas com um batch de tarefas compartilhado
+func (r *Resolver) newLists(lists []models.TaskList) []*listResolver {
+	ids := make([]uint, len(lists))
+	for i, list := range lists {
+		ids[i] = list.ID
+	}
+	tasks := newBatch(ids, r.fetchTasksByList)
+
+	resolvers := make([]*listResolver, len(lists))
+	for i := range lists {
+		resolvers[i] = &listResolver{root: r, list: lists[i], tasks: tasks}
+	}
+	return resolvers
+}
+
+// newTasks cria os resolvers das tarefas. Se a lista já é conhecida (tarefas
+// de list { tasks }), ela é reaproveitada; senão as listas vêm de um batch.
+func (r *Resolver) newTasks(tasks []models.Task, parent *listResolver) []*taskResolver {
+	var lists *batch[uint, *listResolver]
+	if parent == nil {
+		seen := make(map[uint]bool)
+		var ids []uint
+		for _, task := range tasks {
+			if !seen[task.ListID] {
+				seen[task.ListID] = true
+				ids = append(ids, task.ListID)
+			}
+		}
+		lists = newBatch(ids, r.fetchListsByID)
+	}
+
+	resolvers := make([]*taskResolver, len(tasks))
+	for i := range tasks {
+		resolvers[i] = &taskResolver{task: tasks[i], parent: parent, lists: lists}
+	}
+	return resolvers
+}
+
+func (r *Resolver) fetchTasksByList(ctx context.Context, listIDs []uint) (map[uint][]models.Task, error) {
+	byList := make(map[uint][]models.Task, len(listIDs))
+	if len(listIDs) == 0 {
+		return byList, nil
+	}
+	tasks, err := r.tasks.GetAllByLists(ctx, listIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		byList[task.ListID] = append(byList[task.ListID], task)
+	}
+	return byList, nil
+}
+
+func (r *Resolver) fetchListsByID(ctx context.Context, ids []uint) (map[uint]*listResolver, error) {
+	byID := make(map[uint]*listResolver, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+	lists, err := r.lists.Find(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	for _, resolver := range r.newLists(lists) {
+		byID[resolver.list.ID] = resolver
+	}
+	return byID, nil
+}
+
+func parseID(ctx context.Context, raw graphql.ID) (uint, error) {
+	id, err := strconv.ParseUint(string(raw), 10, 32)
+	if err != nil || id == 0 {
+		return 0, fail(ctx, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+	}
+	return uint(id), nil
+}
+
+func formatID(id uint) graphql.ID {
+	return graphql.ID(strconv.FormatUint(uint64(id), 10))
+}
@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"context"
+	"github.com/graph-gophers/graphql-go"
+	"listaPro/internal/apierror"
+	"listaPro/internal/models"
+	"net/http"
+	"strconv"
+)
+
+type listResolver struct {
+	root  *Resolver
+	list  models.TaskList
+	tasks *batch[uint, []models.Task]
+}
+
+func (l *listResolver) ID() graphql.ID {
+	return formatID(l.list.ID)
+}
+
+func (l *listResolver) Name() string {
+	return l.list.Name
+}
+
+func (l *listResolver) Tasks(ctx context.Context) ([]*taskResolver, error) {
+	tasks, err := l.tasks.load(ctx, l.list.ID)
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_tasks_failed"))
+	}
+	return l.root.newTasks(tasks, l), nil
+}
+
+// Version é o mesmo valor do ETag da API REST, sem as aspas
+func (l *listResolver) Version() string {
+	return strconv.FormatInt(l.list.ChangeSeq, 10)
+}
+
+func (l *listResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: l.list.CreatedAt}
+}
+
+func (l *listResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: l.list.UpdatedAt}
+}
+
+type taskResolver struct {
+	task   models.Task
+	parent *listResolver
+	lists  *batch[uint, *listResolver]
+}
+
+func (t *taskResolver) ID() graphql.ID {
+	return formatID(t.task.ID)
+}
+
+func (t *taskResolver) Text() string {
+	return t.task.Text
+}
+
+func (t *taskResolver) IsCompleted() bool {
+	return t.task.IsCompleted
+}
+
+func (t *taskResolver) ListID() graphql.ID {
+	return formatID(t.task.ListID)
+}
+
+func (t *taskResolver) ParentID() *graphql.ID {
+	if t.task.ParentID == nil {
+		return nil
+	}
+	id := formatID(*t.task.ParentID)
+	return &id
+}
+
+func (t *taskResolver) List(ctx context.Context) (*listResolver, error) {
+	if t.parent != nil {
+		return t.parent, nil
+	}
+	list, err := t.lists.load(ctx, t.task.ListID)
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_list_failed"))
+	}
+	return list, nil
+}
+
+func (t *taskResolver) Version() string {
+	return strconv.FormatInt(t.task.ChangeSeq, 10)
+}
+
+func (t *taskResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: t.task.CreatedAt}
+}
+
+func (t *taskResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: t.task.UpdatedAt}
+}
@@ -0,0 +1,72 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"listaPro/internal/events"
+	"listaPro/internal/grpcapi/listaprov1"
+	"listaPro/internal/models"
+)
+
+func toList(list *models.TaskList) *listaprov1.TaskList {
+	pb := &listaprov1.TaskList{
+		Id:        uint64(list.ID),
+		Name:      list.Name,
+		Version:   list.ChangeSeq,
+		CreatedAt: timestamppb.New(list.CreatedAt),
+		UpdatedAt: timestamppb.New(list.UpdatedAt),
+	}
+	for i := range list.Tasks {
+		pb.Tasks = append(pb.Tasks, toTask(&list.Tasks[i]))
+	}
+	return pb
+}
+
+func toTask(task *models.Task) *listaprov1.Task {
+	pb := &listaprov1.Task{
+		Id:          uint64(task.ID),
+		ListId:      uint64(task.ListID),
+		Text:        task.Text,
+		IsCompleted: task.IsCompleted,
+		Version:     task.ChangeSeq,
+		CreatedAt:   timestamppb.New(task.CreatedAt),
+		UpdatedAt:   timestamppb.New(task.UpdatedAt),
+	}
+	if task.ParentID != nil {
+		parentID := uint64(*task.ParentID)
+		pb.ParentId = &parentID
+	}
+	return pb
+}
+
+func toEvent(event events.Event) *listaprov1.Event {
+	pb := &listaprov1.Event{
+		Id:     event.ID,
+		Type:   event.Type,
+		ListId: uint64(event.ListID),
+		Time:   timestamppb.New(event.Time),
+	}
+
+	switch data := event.Data.(type) {
+	case models.TaskList:
+		pb.Payload = &listaprov1.Event_List{List: toList(&data)}
+	case *models.TaskList:
+		pb.Payload = &listaprov1.Event_List{List: toList(data)}
+	case models.Task:
+		pb.Payload = &listaprov1.Event_Task{Task: toTask(&data)}
+	case *models.Task:
+		pb.Payload = &listaprov1.Event_Task{Task: toTask(data)}
+	default:
+		switch event.Type {
+		case events.ListDeleted:
+			pb.Payload = &listaprov1.Event_DeletedId{DeletedId: uint64(event.ListID)}
+		case events.TaskDeleted:
+			// os handlers publicam {"ID": ...} em mapas de tipos diferentes
+			var deleted struct{ ID uint }
+			if raw, err := json.Marshal(data); err == nil && json.Unmarshal(raw, &deleted) == nil {
+				pb.Payload = &listaprov1.Event_DeletedId{DeletedId: uint64(deleted.ID)}
+			}
+		}
+	}
+	return pb
+}
@@ -0,0 +1,59 @@
+package grpcapi
+
+import (
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"listaPro/internal/apierror"
+	"listaPro/internal/i18n"
+	"listaPro/internal/logging"
+	"net/http"
+)
+
+// errorDomain identifica os códigos do apierror no ErrorInfo
+const errorDomain = "listapro"
+
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.Aborted,
+	http.StatusPreconditionFailed:    codes.FailedPrecondition,
+	http.StatusUnprocessableEntity:   codes.InvalidArgument,
+	http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+	http.StatusInternalServerError:   codes.Internal,
+}
+
+// fail converte o erro de API em status gRPC, com a mensagem no idioma do
+// metadata accept-language e o código estável em um ErrorInfo
+func fail(ctx context.Context, err *apierror.Error) error {
+	code, ok := grpcCodes[err.Status]
+	if !ok {
+		code = codes.Unknown
+	}
+	if code == codes.Internal {
+		logging.FromContext(ctx).Error("erro em grpc", logging.Err(err))
+	}
+
+	st := status.New(code, i18n.T(language(ctx), err.Message, err.Args...))
+	info := &errdetails.ErrorInfo{Reason: err.Code, Domain: errorDomain}
+	if len(err.Fields) > 0 {
+		info.Metadata = make(map[string]string, len(err.Fields))
+		for _, field := range err.Fields {
+			info.Metadata[field.Field] = field.Code
+		}
+	}
+	if detailed, detailErr := st.WithDetails(info); detailErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func language(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("accept-language"); len(values) > 0 {
+		return i18n.Negotiate(values[0])
+	}
+	return i18n.Default
+}
@@ -0,0 +1,1163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.3
+// source: listapro/v1/listapro.proto
+
+// API gRPC de listas e tarefas, servida pelo mesmo binário da API REST
+// em uma porta separada (GRPC_PORT).
+
+package listaprov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskList struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Tasks []*Task                `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// mesmo valor do ETag da API REST
+	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskList) Reset() {
+	*x = TaskList{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskList) ProtoMessage() {}
+
+func (x *TaskList) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskList.ProtoReflect.Descriptor instead.
+func (*TaskList) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TaskList) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskList) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaskList) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *TaskList) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TaskList) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TaskList) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Task struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ListId        uint64                 `protobuf:"varint,2,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	ParentId      *uint64                `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	IsCompleted   bool                   `protobuf:"varint,5,opt,name=is_completed,json=isCompleted,proto3" json:"is_completed,omitempty"`
+	Version       int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Task) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Task) GetListId() uint64 {
+	if x != nil {
+		return x.ListId
+	}
+	return 0
+}
+
+func (x *Task) GetParentId() uint64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *Task) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Task) GetIsCompleted() bool {
+	if x != nil {
+		return x.IsCompleted
+	}
+	return false
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// list.created, task.updated etc.
+	Type   string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ListId uint64                 `protobuf:"varint,3,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	Time   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Event_List
+	//	*Event_Task
+	//	*Event_DeletedId
+	Payload       isEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Event) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetListId() uint64 {
+	if x != nil {
+		return x.ListId
+	}
+	return 0
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetPayload() isEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetList() *TaskList {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_List); ok {
+			return x.List
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTask() *Task {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Task); ok {
+			return x.Task
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetDeletedId() uint64 {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_DeletedId); ok {
+			return x.DeletedId
+		}
+	}
+	return 0
+}
+
+type isEvent_Payload interface {
+	isEvent_Payload()
+}
+
+type Event_List struct {
+	List *TaskList `protobuf:"bytes,5,opt,name=list,proto3,oneof"`
+}
+
+type Event_Task struct {
+	Task *Task `protobuf:"bytes,6,opt,name=task,proto3,oneof"`
+}
+
+type Event_DeletedId struct {
+	// ID da lista ou tarefa removida
+	DeletedId uint64 `protobuf:"varint,7,opt,name=deleted_id,json=deletedId,proto3,oneof"`
+}
+
+func (*Event_List) isEvent_Payload() {}
+
+func (*Event_Task) isEvent_Payload() {}
+
+func (*Event_DeletedId) isEvent_Payload() {}
+
+type ListListsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncludeTasks  bool                   `protobuf:"varint,1,opt,name=include_tasks,json=includeTasks,proto3" json:"include_tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListListsRequest) Reset() {
+	*x = ListListsRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListListsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListListsRequest) ProtoMessage() {}
+
+func (x *ListListsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListListsRequest.ProtoReflect.Descriptor instead.
+func (*ListListsRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListListsRequest) GetIncludeTasks() bool {
+	if x != nil {
+		return x.IncludeTasks
+	}
+	return false
+}
+
+type ListListsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lists         []*TaskList            `protobuf:"bytes,1,rep,name=lists,proto3" json:"lists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListListsResponse) Reset() {
+	*x = ListListsResponse{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListListsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListListsResponse) ProtoMessage() {}
+
+func (x *ListListsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListListsResponse.ProtoReflect.Descriptor instead.
+func (*ListListsResponse) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListListsResponse) GetLists() []*TaskList {
+	if x != nil {
+		return x.Lists
+	}
+	return nil
+}
+
+type GetListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListRequest) Reset() {
+	*x = GetListRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListRequest) ProtoMessage() {}
+
+func (x *GetListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListRequest.ProtoReflect.Descriptor instead.
+func (*GetListRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetListRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateListRequest) Reset() {
+	*x = CreateListRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateListRequest) ProtoMessage() {}
+
+func (x *CreateListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateListRequest.ProtoReflect.Descriptor instead.
+func (*CreateListRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateListRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateListRequest) Reset() {
+	*x = UpdateListRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateListRequest) ProtoMessage() {}
+
+func (x *UpdateListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateListRequest.ProtoReflect.Descriptor instead.
+func (*UpdateListRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateListRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateListRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteListRequest) Reset() {
+	*x = DeleteListRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteListRequest) ProtoMessage() {}
+
+func (x *DeleteListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteListRequest.ProtoReflect.Descriptor instead.
+func (*DeleteListRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteListRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WatchListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchListRequest) Reset() {
+	*x = WatchListRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchListRequest) ProtoMessage() {}
+
+func (x *WatchListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchListRequest.ProtoReflect.Descriptor instead.
+func (*WatchListRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchListRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListId        *uint64                `protobuf:"varint,1,opt,name=list_id,json=listId,proto3,oneof" json:"list_id,omitempty"`
+	IsCompleted   *bool                  `protobuf:"varint,2,opt,name=is_completed,json=isCompleted,proto3,oneof" json:"is_completed,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListTasksRequest) GetListId() uint64 {
+	if x != nil && x.ListId != nil {
+		return *x.ListId
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetIsCompleted() bool {
+	if x != nil && x.IsCompleted != nil {
+		return *x.IsCompleted
+	}
+	return false
+}
+
+func (x *ListTasksRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTaskRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListId        uint64                 `protobuf:"varint,1,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateTaskRequest) GetListId() uint64 {
+	if x != nil {
+		return x.ListId
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type UpdateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          *string                `protobuf:"bytes,2,opt,name=text,proto3,oneof" json:"text,omitempty"`
+	IsCompleted   *bool                  `protobuf:"varint,3,opt,name=is_completed,json=isCompleted,proto3,oneof" json:"is_completed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateTaskRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetText() string {
+	if x != nil && x.Text != nil {
+		return *x.Text
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetIsCompleted() bool {
+	if x != nil && x.IsCompleted != nil {
+		return *x.IsCompleted
+	}
+	return false
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_listapro_v1_listapro_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listapro_v1_listapro_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_listapro_v1_listapro_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteTaskRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_listapro_v1_listapro_proto protoreflect.FileDescriptor
+
+const file_listapro_v1_listapro_proto_rawDesc = "" +
+	"\n" +
+	"\x1alistapro/v1/listapro.proto\x12\vlistapro.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe7\x01\n" +
+	"\bTaskList\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12'\n" +
+	"\x05tasks\x18\x03 \x03(\v2\x11.listapro.v1.TaskR\x05tasks\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa6\x02\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\alist_id\x18\x02 \x01(\x04R\x06listId\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x04H\x00R\bparentId\x88\x01\x01\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12!\n" +
+	"\fis_completed\x18\x05 \x01(\bR\visCompleted\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\f\n" +
+	"\n" +
+	"_parent_id\"\xf6\x01\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x17\n" +
+	"\alist_id\x18\x03 \x01(\x04R\x06listId\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12+\n" +
+	"\x04list\x18\x05 \x01(\v2\x15.listapro.v1.TaskListH\x00R\x04list\x12'\n" +
+	"\x04task\x18\x06 \x01(\v2\x11.listapro.v1.TaskH\x00R\x04task\x12\x1f\n" +
+	"\n" +
+	"deleted_id\x18\a \x01(\x04H\x00R\tdeletedIdB\t\n" +
+	"\apayload\"7\n" +
+	"\x10ListListsRequest\x12#\n" +
+	"\rinclude_tasks\x18\x01 \x01(\bR\fincludeTasks\"@\n" +
+	"\x11ListListsResponse\x12+\n" +
+	"\x05lists\x18\x01 \x03(\v2\x15.listapro.v1.TaskListR\x05lists\" \n" +
+	"\x0eGetListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"'\n" +
+	"\x11CreateListRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"7\n" +
+	"\x11UpdateListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"#\n" +
+	"\x11DeleteListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\"\n" +
+	"\x10WatchListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x89\x01\n" +
+	"\x10ListTasksRequest\x12\x1c\n" +
+	"\alist_id\x18\x01 \x01(\x04H\x00R\x06listId\x88\x01\x01\x12&\n" +
+	"\fis_completed\x18\x02 \x01(\bH\x01R\visCompleted\x88\x01\x01\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04textB\n" +
+	"\n" +
+	"\b_list_idB\x0f\n" +
+	"\r_is_completed\"<\n" +
+	"\x11ListTasksResponse\x12'\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x11.listapro.v1.TaskR\x05tasks\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"@\n" +
+	"\x11CreateTaskRequest\x12\x17\n" +
+	"\alist_id\x18\x01 \x01(\x04R\x06listId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"~\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\x04text\x18\x02 \x01(\tH\x00R\x04text\x88\x01\x01\x12&\n" +
+	"\fis_completed\x18\x03 \x01(\bH\x01R\visCompleted\x88\x01\x01B\a\n" +
+	"\x05_textB\x0f\n" +
+	"\r_is_completed\"#\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id2\xaa\x03\n" +
+	"\vListService\x12J\n" +
+	"\tListLists\x12\x1d.listapro.v1.ListListsRequest\x1a\x1e.listapro.v1.ListListsResponse\x12=\n" +
+	"\aGetList\x12\x1b.listapro.v1.GetListRequest\x1a\x15.listapro.v1.TaskList\x12C\n" +
+	"\n" +
+	"CreateList\x12\x1e.listapro.v1.CreateListRequest\x1a\x15.listapro.v1.TaskList\x12C\n" +
+	"\n" +
+	"UpdateList\x12\x1e.listapro.v1.UpdateListRequest\x1a\x15.listapro.v1.TaskList\x12D\n" +
+	"\n" +
+	"DeleteList\x12\x1e.listapro.v1.DeleteListRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\tWatchList\x12\x1d.listapro.v1.WatchListRequest\x1a\x12.listapro.v1.Event0\x012\xdc\x02\n" +
+	"\vTaskService\x12J\n" +
+	"\tListTasks\x12\x1d.listapro.v1.ListTasksRequest\x1a\x1e.listapro.v1.ListTasksResponse\x129\n" +
+	"\aGetTask\x12\x1b.listapro.v1.GetTaskRequest\x1a\x11.listapro.v1.Task\x12?\n" +
+	"\n" +
+	"CreateTask\x12\x1e.listapro.v1.CreateTaskRequest\x1a\x11.listapro.v1.Task\x12?\n" +
+	"\n" +
+	"UpdateTask\x12\x1e.listapro.v1.UpdateTaskRequest\x1a\x11.listapro.v1.Task\x12D\n" +
+	"\n" +
+	"DeleteTask\x12\x1e.listapro.v1.DeleteTaskRequest\x1a\x16.google.protobuf.EmptyB1Z/listaPro/internal/grpcapi/listaprov1;listaprov1b\x06proto3"
+
+var (
+	file_listapro_v1_listapro_proto_rawDescOnce sync.Once
+	file_listapro_v1_listapro_proto_rawDescData []byte
+)
+
+func file_listapro_v1_listapro_proto_rawDescGZIP() []byte {
+	file_listapro_v1_listapro_proto_rawDescOnce.Do(func() {
+		file_listapro_v1_listapro_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_listapro_v1_listapro_proto_rawDesc), len(file_listapro_v1_listapro_proto_rawDesc)))
+	})
+	return file_listapro_v1_listapro_proto_rawDescData
+}
+
+var file_listapro_v1_listapro_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_listapro_v1_listapro_proto_goTypes = []any{
+	(*TaskList)(nil),              // 0: listapro.v1.TaskList
+	(*Task)(nil),                  // 1: listapro.v1.Task
+	(*Event)(nil),                 // 2: listapro.v1.Event
+	(*ListListsRequest)(nil),      // 3: listapro.v1.ListListsRequest
+	(*ListListsResponse)(nil),     // 4: listapro.v1.ListListsResponse
+	(*GetListRequest)(nil),        // 5: listapro.v1.GetListRequest
+	(*CreateListRequest)(nil),     // 6: listapro.v1.CreateListRequest
+	(*UpdateListRequest)(nil),     // 7: listapro.v1.UpdateListRequest
+	(*DeleteListRequest)(nil),     // 8: listapro.v1.DeleteListRequest
+	(*WatchListRequest)(nil),      // 9: listapro.v1.WatchListRequest
+	(*ListTasksRequest)(nil),      // 10: listapro.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),     // 11: listapro.v1.ListTasksResponse
+	(*GetTaskRequest)(nil),        // 12: listapro.v1.GetTaskRequest
+	(*CreateTaskRequest)(nil),     // 13: listapro.v1.CreateTaskRequest
+	(*UpdateTaskRequest)(nil),     // 14: listapro.v1.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),     // 15: listapro.v1.DeleteTaskRequest
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 17: google.protobuf.Empty
+}
+var file_listapro_v1_listapro_proto_depIdxs = []int32{
+	1,  // 0: listapro.v1.TaskList.tasks:type_name -> listapro.v1.Task
+	16, // 1: listapro.v1.TaskList.created_at:type_name -> google.protobuf.Timestamp
+	16, // 2: listapro.v1.TaskList.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 3: listapro.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	16, // 4: listapro.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 5: listapro.v1.Event.time:type_name -> google.protobuf.Timestamp
+	0,  // 6: listapro.v1.Event.list:type_name -> listapro.v1.TaskList
+	1,  // 7: listapro.v1.Event.task:type_name -> listapro.v1.Task
+	0,  // 8: listapro.v1.ListListsResponse.lists:type_name -> listapro.v1.TaskList
+	1,  // 9: listapro.v1.ListTasksResponse.tasks:type_name -> listapro.v1.Task
+	3,  // 10: listapro.v1.ListService.ListLists:input_type -> listapro.v1.ListListsRequest
+	5,  // 11: listapro.v1.ListService.GetList:input_type -> listapro.v1.GetListRequest
+	6,  // 12: listapro.v1.ListService.CreateList:input_type -> listapro.v1.CreateListRequest
+	7,  // 13: listapro.v1.ListService.UpdateList:input_type -> listapro.v1.UpdateListRequest
+	8,  // 14: listapro.v1.ListService.DeleteList:input_type -> listapro.v1.DeleteListRequest
+	9,  // 15: listapro.v1.ListService.WatchList:input_type -> listapro.v1.WatchListRequest
+	10, // 16: listapro.v1.TaskService.ListTasks:input_type -> listapro.v1.ListTasksRequest
+	12, // 17: listapro.v1.TaskService.GetTask:input_type -> listapro.v1.GetTaskRequest
+	13, // 18: listapro.v1.TaskService.CreateTask:input_type -> listapro.v1.CreateTaskRequest
+	14, // 19: listapro.v1.TaskService.UpdateTask:input_type -> listapro.v1.UpdateTaskRequest
+	15, // 20: listapro.v1.TaskService.DeleteTask:input_type -> listapro.v1.DeleteTaskRequest
+	4,  // 21: listapro.v1.ListService.ListLists:output_type -> listapro.v1.ListListsResponse
+	0,  // 22: listapro.v1.ListService.GetList:output_type -> listapro.v1.TaskList
+	0,  // 23: listapro.v1.ListService.CreateList:output_type -> listapro.v1.TaskList
+	0,  // 24: listapro.v1.ListService.UpdateList:output_type -> listapro.v1.TaskList
+	17, // 25: listapro.v1.ListService.DeleteList:output_type -> google.protobuf.Empty
+	2,  // 26: listapro.v1.ListService.WatchList:output_type -> listapro.v1.Event
+	11, // 27: listapro.v1.TaskService.ListTasks:output_type -> listapro.v1.ListTasksResponse
+	1,  // 28: listapro.v1.TaskService.GetTask:output_type -> listapro.v1.Task
+	1,  // 29: listapro.v1.TaskService.CreateTask:output_type -> listapro.v1.Task
+	1,  // 30: listapro.v1.TaskService.UpdateTask:output_type -> listapro.v1.Task
+	17, // 31: listapro.v1.TaskService.DeleteTask:output_type -> google.protobuf.Empty
+	21, // [21:32] is the sub-list for method output_type
+	10, // [10:21] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_listapro_v1_listapro_proto_init() }
+func file_listapro_v1_listapro_proto_init() {
+	if File_listapro_v1_listapro_proto != nil {
+		return
+	}
+	file_listapro_v1_listapro_proto_msgTypes[1].OneofWrappers = []any{}
+	file_listapro_v1_listapro_proto_msgTypes[2].OneofWrappers = []any{
+		(*Event_List)(nil),
+		(*Event_Task)(nil),
+		(*Event_DeletedId)(nil),
+	}
+	file_listapro_v1_listapro_proto_msgTypes[10].OneofWrappers = []any{}
+	file_listapro_v1_listapro_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_listapro_v1_listapro_proto_rawDesc), len(file_listapro_v1_listapro_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_listapro_v1_listapro_proto_goTypes,
+		DependencyIndexes: file_listapro_v1_listapro_proto_depIdxs,
+		MessageInfos:      file_listapro_v1_listapro_proto_msgTypes,
+	}.Build()
+	File_listapro_v1_listapro_proto = out.File
+	file_listapro_v1_listapro_proto_goTypes = nil
+	file_listapro_v1_listapro_proto_depIdxs = nil
+}
@@ -0,0 +1,173 @@
+package grpcapi
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"listaPro/internal/apierror"
+	"listaPro/internal/events"
+	"listaPro/internal/grpcapi/listaprov1"
+	"listaPro/internal/models"
+	"listaPro/internal/repositories"
+	"math"
+	"net/http"
+)
+
+type listServer struct {
+	listaprov1.UnimplementedListServiceServer
+	lists repositories.ListStore
+	tasks repositories.TaskStore
+}
+
+func (s *listServer) ListLists(ctx context.Context, req *listaprov1.ListListsRequest) (*listaprov1.ListListsResponse, error) {
+	lists, err := s.lists.Find(ctx)
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_lists_failed"))
+	}
+
+	// as tarefas de todas as listas vêm em uma única consulta
+	if req.GetIncludeTasks() && len(lists) > 0 {
+		if err := s.loadTasks(ctx, lists); err != nil {
+			return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_tasks_failed"))
+		}
+	}
+
+	resp := &listaprov1.ListListsResponse{}
+	for i := range lists {
+		resp.Lists = append(resp.Lists, toList(&lists[i]))
+	}
+	return resp, nil
+}
+
+func (s *listServer) GetList(ctx context.Context, req *listaprov1.GetListRequest) (*listaprov1.TaskList, error) {
+	list, err := findList(ctx, s.lists, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	lists := []models.TaskList{*list}
+	if err := s.loadTasks(ctx, lists); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_tasks_failed"))
+	}
+	return toList(&lists[0]), nil
+}
+
+func (s *listServer) CreateList(ctx context.Context, req *listaprov1.CreateListRequest) (*listaprov1.TaskList, error) {
+	if req.GetName() == "" {
+		return nil, fail(ctx, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "name").WithField("name", "required"))
+	}
+
+	list := models.TaskList{Name: req.GetName()}
+	if err := s.lists.Create(ctx, &list); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "create_list_failed"))
+	}
+
+	events.Publish(events.ListCreated, list.ID, list)
+	return toList(&list), nil
+}
+
+func (s *listServer) UpdateList(ctx context.Context, req *listaprov1.UpdateListRequest) (*listaprov1.TaskList, error) {
+	if req.GetName() == "" {
+		return nil, fail(ctx, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "name").WithField("name", "required"))
+	}
+	list, err := findList(ctx, s.lists, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	list.Name = req.GetName()
+	if err := s.lists.Update(ctx, list); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "update_list_failed"))
+	}
+
+	events.Publish(events.ListUpdated, list.ID, list)
+	return toList(list), nil
+}
+
+func (s *listServer) DeleteList(ctx context.Context, req *listaprov1.DeleteListRequest) (*emptypb.Empty, error) {
+	list, err := findList(ctx, s.lists, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.lists.Delete(ctx, list); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "delete_list_failed"))
+	}
+
+	events.Publish(events.ListDeleted, list.ID, nil)
+	return &emptypb.Empty{}, nil
+}
+
+// WatchList assina os eventos da lista e os repassa até o cliente cancelar
+func (s *listServer) WatchList(req *listaprov1.WatchListRequest, stream grpc.ServerStreamingServer[listaprov1.Event]) error {
+	ctx := stream.Context()
+
+	// assina antes de conferir a lista para não perder eventos no meio
+	sub := events.SubscribeStream(uint(req.GetId()))
+	defer sub.Close()
+
+	if _, err := findList(ctx, s.lists, req.GetId()); err != nil {
+		return err
+	}
+	// os cabeçalhos avisam o cliente de que a assinatura já está ativa
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.C:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(toEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// loadTasks preenche as tarefas das listas com uma única consulta
+func (s *listServer) loadTasks(ctx context.Context, lists []models.TaskList) error {
+	ids := make([]uint, len(lists))
+	for i, list := range lists {
+		ids[i] = list.ID
+	}
+	tasks, err := s.tasks.GetAllByLists(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byList := make(map[uint][]models.Task, len(lists))
+	for _, task := range tasks {
+		byList[task.ListID] = append(byList[task.ListID], task)
+	}
+	for i := range lists {
+		lists[i].Tasks = byList[lists[i].ID]
+	}
+	return nil
+}
+
+func findList(ctx context.Context, store repositories.ListStore, rawID uint64) (*models.TaskList, error) {
+	id, err := parseID(ctx, rawID)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := store.Find(ctx, id)
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_list_failed"))
+	}
+	if len(lists) == 0 {
+		return nil, fail(ctx, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
+	}
+	return &lists[0], nil
+}
+
+func parseID(ctx context.Context, raw uint64) (uint, error) {
+	if raw == 0 || raw > math.MaxUint32 {
+		return 0, fail(ctx, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+	}
+	return uint(raw), nil
+}
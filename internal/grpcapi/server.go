@@ -0,0 +1,28 @@
+// Package grpcapi serve a API gRPC (listapro.v1) usando os mesmos
+// repositórios e eventos dos handlers REST.
+//
+// Os arquivos em listaprov1 são gerados a partir de proto/listapro/v1:
+//
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=listaPro --go-grpc_out=../.. --go-grpc_opt=module=listaPro listapro/v1/listapro.proto
+package grpcapi
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"listaPro/internal/grpcapi/listaprov1"
+	"listaPro/internal/repositories"
+)
+
+// NewServer cria o servidor gRPC com os serviços de listas e tarefas. Cada
+// chamada ganha um span, que continua o traceparent do cliente e é o pai das
+// consultas feitas pelos repositórios.
+func NewServer(lists repositories.ListStore, tasks repositories.TaskStore, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler())}, opts...)
+	server := grpc.NewServer(opts...)
+	listaprov1.RegisterListServiceServer(server, &listServer{lists: lists, tasks: tasks})
+	listaprov1.RegisterTaskServiceServer(server, &taskServer{lists: lists, tasks: tasks})
+	// permite usar grpcurl sem o arquivo .proto
+	reflection.Register(server)
+	return server
+}
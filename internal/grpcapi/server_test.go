@@ -0,0 +1,222 @@
+package grpcapi
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+	"listaPro/internal/events"
+	"listaPro/internal/grpcapi/listaprov1"
+	"listaPro/internal/models"
+	"listaPro/internal/repositories"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore implementa ListStore e TaskStore em memória
+type memoryStore struct {
+	mu      sync.Mutex
+	lists   []models.TaskList
+	tasks   []models.Task
+	findCtx context.Context // contexto recebido pelo último Find
+}
+
+func (s *memoryStore) Find(ctx context.Context, ids ...uint) ([]models.TaskList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.findCtx = ctx
+	var found []models.TaskList
+	for _, list := range s.lists {
+		if len(ids) == 0 || containsID(ids, list.ID) {
+			found = append(found, list)
+		}
+	}
+	return found, nil
+}
+
+func (s *memoryStore) Create(_ context.Context, list *models.TaskList) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list.ID = uint(len(s.lists) + 1)
+	s.lists = append(s.lists, *list)
+	return nil
+}
+
+func (s *memoryStore) Update(_ context.Context, list *models.TaskList) error { return nil }
+func (s *memoryStore) Delete(_ context.Context, list *models.TaskList) error { return nil }
+
+type memoryTaskStore struct{ *memoryStore }
+
+func (s memoryTaskStore) GetByID(_ context.Context, id uint) (*models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, task := range s.tasks {
+		if task.ID == id {
+			return &task, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s memoryTaskStore) GetAllByLists(_ context.Context, listIDs []uint) ([]models.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found []models.Task
+	for _, task := range s.tasks {
+		if containsID(listIDs, task.ListID) {
+			found = append(found, task)
+		}
+	}
+	return found, nil
+}
+
+func (s memoryTaskStore) Search(ctx context.Context, filter repositories.TaskFilter) ([]models.Task, error) {
+	return s.GetAllByLists(ctx, []uint{*filter.ListID})
+}
+
+func (s memoryTaskStore) Create(_ context.Context, task *models.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task.ID = uint(len(s.tasks) + 1)
+	s.tasks = append(s.tasks, *task)
+	return nil
+}
+
+func (s memoryTaskStore) Update(_ context.Context, task *models.Task, fields map[string]interface{}) error {
+	return nil
+}
+func (s memoryTaskStore) Delete(_ context.Context, task *models.Task) error { return nil }
+
+func containsID(ids []uint, id uint) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func dial(t *testing.T) (listaprov1.ListServiceClient, listaprov1.TaskServiceClient) {
+	return dialStore(t, &memoryStore{
+		lists: []models.TaskList{{Model: gorm.Model{ID: 1}, Name: "Mercado"}},
+		tasks: []models.Task{{Model: gorm.Model{ID: 1}, Text: "Leite", ListID: 1}},
+	})
+}
+
+func dialStore(t *testing.T, store *memoryStore) (listaprov1.ListServiceClient, listaprov1.TaskServiceClient) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewServer(store, memoryTaskStore{store})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return listaprov1.NewListServiceClient(conn), listaprov1.NewTaskServiceClient(conn)
+}
+
+func TestListService(t *testing.T) {
+	lists, _ := dial(t)
+	ctx := context.Background()
+
+	t.Run("Deve retornar a lista com as tarefas", func(t *testing.T) {
+		list, err := lists.GetList(ctx, &listaprov1.GetListRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "Mercado", list.GetName())
+		require.Len(t, list.GetTasks(), 1)
+		assert.Equal(t, "Leite", list.GetTasks()[0].GetText())
+	})
+
+	t.Run("Lista inexistente", func(t *testing.T) {
+		_, err := lists.GetList(ctx, &listaprov1.GetListRequest{Id: 99})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Nome obrigatório com código e idioma", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(ctx, "accept-language", "en")
+		_, err := lists.CreateList(ctx, &listaprov1.CreateListRequest{})
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, "The name field is required", st.Message())
+		require.Len(t, st.Details(), 1)
+		info := st.Details()[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, "validation_failed", info.GetReason())
+		assert.Equal(t, "required", info.GetMetadata()["name"])
+	})
+}
+
+func TestWatchList(t *testing.T) {
+	lists, tasks := dial(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := lists.WatchList(ctx, &listaprov1.WatchListRequest{Id: 1})
+	require.NoError(t, err)
+
+	// os cabeçalhos só chegam depois que o servidor assinou os eventos
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	_, err = tasks.CreateTask(ctx, &listaprov1.CreateTaskRequest{ListId: 1, Text: "Café"})
+	require.NoError(t, err)
+
+	for {
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		if event.GetType() != events.TaskCreated || event.GetTask().GetText() != "Café" {
+			continue
+		}
+		assert.Equal(t, uint64(1), event.GetListId())
+		return
+	}
+}
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	store := &memoryStore{lists: []models.TaskList{{Model: gorm.Model{ID: 1}, Name: "Mercado"}}}
+	lists, _ := dialStore(t, store)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := lists.ListLists(ctx, &listaprov1.ListListsRequest{})
+	require.NoError(t, err)
+
+	t.Run("A chamada vira um span que continua o traceparent", func(t *testing.T) {
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "listapro.v1.ListService/ListLists", spans[0].Name)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	})
+
+	t.Run("O repositório recebe o contexto com o span da chamada", func(t *testing.T) {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		span := trace.SpanContextFromContext(store.findCtx)
+		assert.Equal(t, exporter.GetSpans()[0].SpanContext.SpanID(), span.SpanID())
+	})
+}
@@ -0,0 +1,120 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+	"listaPro/internal/apierror"
+	"listaPro/internal/events"
+	"listaPro/internal/grpcapi/listaprov1"
+	"listaPro/internal/models"
+	"listaPro/internal/repositories"
+	"net/http"
+)
+
+type taskServer struct {
+	listaprov1.UnimplementedTaskServiceServer
+	lists repositories.ListStore
+	tasks repositories.TaskStore
+}
+
+func (s *taskServer) ListTasks(ctx context.Context, req *listaprov1.ListTasksRequest) (*listaprov1.ListTasksResponse, error) {
+	filter := repositories.TaskFilter{IsCompleted: req.IsCompleted, Text: req.GetText()}
+	if req.ListId != nil {
+		listID, err := parseID(ctx, req.GetListId())
+		if err != nil {
+			return nil, err
+		}
+		filter.ListID = &listID
+	}
+
+	tasks, err := s.tasks.Search(ctx, filter)
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_tasks_failed"))
+	}
+
+	resp := &listaprov1.ListTasksResponse{}
+	for i := range tasks {
+		resp.Tasks = append(resp.Tasks, toTask(&tasks[i]))
+	}
+	return resp, nil
+}
+
+func (s *taskServer) GetTask(ctx context.Context, req *listaprov1.GetTaskRequest) (*listaprov1.Task, error) {
+	task, err := s.findTask(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *taskServer) CreateTask(ctx context.Context, req *listaprov1.CreateTaskRequest) (*listaprov1.Task, error) {
+	if req.GetText() == "" {
+		return nil, fail(ctx, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "text").WithField("text", "required"))
+	}
+	list, err := findList(ctx, s.lists, req.GetListId())
+	if err != nil {
+		return nil, err
+	}
+
+	task := models.Task{Text: req.GetText(), ListID: list.ID}
+	if err := s.tasks.Create(ctx, &task); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "create_task_failed"))
+	}
+
+	events.Publish(events.TaskCreated, task.ListID, task)
+	return toTask(&task), nil
+}
+
+func (s *taskServer) UpdateTask(ctx context.Context, req *listaprov1.UpdateTaskRequest) (*listaprov1.Task, error) {
+	if req.Text != nil && req.GetText() == "" {
+		return nil, fail(ctx, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "text").WithField("text", "required"))
+	}
+	task, err := s.findTask(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if req.Text != nil {
+		fields["text"] = req.GetText()
+	}
+	if req.IsCompleted != nil {
+		fields["is_completed"] = req.GetIsCompleted()
+	}
+	if err := s.tasks.Update(ctx, task, fields); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "update_task_failed"))
+	}
+
+	events.Publish(events.TaskUpdated, task.ListID, task)
+	return toTask(task), nil
+}
+
+func (s *taskServer) DeleteTask(ctx context.Context, req *listaprov1.DeleteTaskRequest) (*emptypb.Empty, error) {
+	task, err := s.findTask(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tasks.Delete(ctx, task); err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "delete_task_failed"))
+	}
+
+	events.Publish(events.TaskDeleted, task.ListID, map[string]uint{"ID": task.ID})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *taskServer) findTask(ctx context.Context, rawID uint64) (*models.Task, error) {
+	id, err := parseID(ctx, rawID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.tasks.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fail(ctx, apierror.New(http.StatusNotFound, apierror.CodeTaskNotFound, "task_not_found"))
+	}
+	if err != nil {
+		return nil, fail(ctx, apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "fetch_task_failed"))
+	}
+	return task, nil
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// requestDB liga as consultas ao contexto da requisição, para que sejam
+// canceladas junto com ela e apareçam no trace. Nos testes de validação o
+// handler recebe db nil e nunca chega a consultar o banco.
+func requestDB(c *gin.Context, db *gorm.DB) *gorm.DB {
+	if db == nil {
+		return nil
+	}
+	return db.WithContext(c.Request.Context())
+}
@@ -0,0 +1,12 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"listaPro/internal/apierror"
+)
+
+// abort registra o erro para o middleware de erros e interrompe a requisição
+func abort(c *gin.Context, err *apierror.Error) {
+	_ = c.Error(err)
+	c.Abort()
+}
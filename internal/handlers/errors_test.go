@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"listaPro/internal/apierror"
+	"listaPro/internal/middleware"
+)
+
+// setupRouter cria um router de teste com o middleware de erros, como em main.go
+func setupRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Language(), middleware.Errors())
+	return router
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	router := setupRouter()
+	router.PUT("/tasks/:id", UpdateTask(nil))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/tasks/abc", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, apierror.ContentType, w.Header().Get("Content-Type"))
+
+	var problem apierror.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, apierror.CodeInvalidID, problem.Code)
+	assert.Equal(t, apierror.TypeURI(apierror.CodeInvalidID), problem.Type)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "/tasks/abc", problem.Instance)
+	assert.Equal(t, "req-123", problem.RequestID)
+	assert.Equal(t, "ID da tarefa inválido", problem.Detail)
+
+	t.Run("Deve traduzir pelo Accept-Language", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/tasks/abc", nil)
+		req.Header.Set("Accept-Language", "fr-FR, en-US;q=0.8, es;q=0.5")
+		router.ServeHTTP(w, req)
+
+		var problem apierror.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "Invalid task ID", problem.Detail)
+		assert.Equal(t, apierror.CodeInvalidID, problem.Code)
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	})
+
+	t.Run("O parâmetro lang tem prioridade", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/tasks/abc?lang=es", nil)
+		req.Header.Set("Accept-Language", "en")
+		router.ServeHTTP(w, req)
+
+		var problem apierror.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "ID de tarea no válido", problem.Detail)
+	})
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"listaPro/internal/apierror"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// versionETag gera o ETag de uma lista ou tarefa a partir do seu ChangeSeq
+func versionETag(seq int64) string {
+	return `"` + strconv.FormatInt(seq, 10) + `"`
+}
+
+// etagMatches verifica se algum ETag do cabeçalho corresponde ao ETag atual.
+// O If-Match usa a comparação forte (RFC 9110, 8.8.3.2), em que ETags fracos
+// nunca correspondem; o If-None-Match usa a fraca, que compara só o valor.
+func etagMatches(header, etag string, strong bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		switch {
+		case candidate == "*":
+			return true
+		case strong:
+			if candidate == etag && !strings.HasPrefix(etag, "W/") {
+				return true
+			}
+		case strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/"):
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch responde 412 quando o cliente envia If-Match e a versão atual
+// do recurso é outra. Retorna se a requisição pode prosseguir e se a
+// precondição foi informada.
+func checkIfMatch(c *gin.Context, seq int64) (ok bool, conditional bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return true, false
+	}
+	if !etagMatches(header, versionETag(seq), true) {
+		c.Header("ETag", versionETag(seq))
+		abort(c, apierror.New(http.StatusPreconditionFailed, apierror.CodePreconditionFailed, "precondition_failed"))
+		return false, true
+	}
+	return true, true
+}
+
+// jsonWithETag responde com o JSON e um ETag calculado sobre o corpo,
+// devolvendo 304 quando o cliente já tem essa versão (If-None-Match). O ETag
+// é fraco: ele identifica a coleção, não a versão de uma lista ou tarefa, e
+// por isso não serve para o If-Match.
+func jsonWithETag(c *gin.Context, status int, obj any) {
+	body, err := json.Marshal(present(c, obj))
+	if err != nil {
+		abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "generate_response_failed"))
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+
+	if header := c.GetHeader("If-None-Match"); header != "" && etagMatches(header, etag, false) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(status, "application/json; charset=utf-8", body)
+}
+
+// jsonWithVersion responde com uma lista ou tarefa e o ETag da sua versão,
+// o mesmo que o If-Match de PUT, PATCH e DELETE espera
+func jsonWithVersion(c *gin.Context, seq int64, obj any) {
+	etag := versionETag(seq)
+	c.Header("ETag", etag)
+
+	if header := c.GetHeader("If-None-Match"); header != "" && etagMatches(header, etag, false) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	render(c, http.StatusOK, obj)
+}
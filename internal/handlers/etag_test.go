@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestEtagMatches(t *testing.T) {
+	t.Run("Comparação fraca (If-None-Match)", func(t *testing.T) {
+		assert.True(t, etagMatches(`"3"`, `"3"`, false))
+		assert.True(t, etagMatches(`"1", W/"3"`, `"3"`, false))
+		assert.True(t, etagMatches(`"3"`, `W/"3"`, false))
+		assert.True(t, etagMatches(`*`, `"3"`, false))
+		assert.False(t, etagMatches(`"2"`, `"3"`, false))
+	})
+
+	t.Run("Comparação forte (If-Match) recusa ETags fracos", func(t *testing.T) {
+		assert.True(t, etagMatches(`"1", "3"`, `"3"`, true))
+		assert.True(t, etagMatches(`*`, `"3"`, true))
+		assert.False(t, etagMatches(`W/"3"`, `"3"`, true))
+		assert.False(t, etagMatches(`"3"`, `W/"3"`, true))
+	})
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := setupRouter()
+	router.PUT("/tasks/:id", func(c *gin.Context) {
+		// simula uma tarefa na versão 5
+		if ok, _ := checkIfMatch(c, 5); !ok {
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	t.Run("Sem If-Match a atualização é livre", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/tasks/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Versão atual deve passar", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/tasks/1", nil)
+		req.Header.Set("If-Match", `"5"`)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("ETag fraco deve retornar 412", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/tasks/1", nil)
+		req.Header.Set("If-Match", `W/"5"`)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("Versão antiga deve retornar 412", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/tasks/1", nil)
+		req.Header.Set("If-Match", `"4"`)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+	})
+}
+
+func TestJSONWithETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := setupRouter()
+	router.GET("/lists", func(c *gin.Context) {
+		jsonWithETag(c, http.StatusOK, []gin.H{{"Name": "Lista 1"}})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/lists", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.True(t, strings.HasPrefix(etag, `W/"`), "o ETag da coleção é fraco: %s", etag)
+
+	t.Run("Mesmo ETag deve retornar 304", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/lists", nil)
+		req.Header.Set("If-None-Match", etag)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("ETag diferente deve retornar o corpo", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/lists", nil)
+		req.Header.Set("If-None-Match", `"outro"`)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Lista 1")
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	// DryRun não executa o SQL: First devolve o registro zerado, na versão 0
+	dryRun, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{
+		DisableAutomaticPing: true, DryRun: true, SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	// nenhum Postgres escuta nessa porta: toda consulta falha
+	unreachable, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=listapro dbname=listapro sslmode=disable connect_timeout=1"),
+		&gorm.Config{DisableAutomaticPing: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	get := func(handler gin.HandlerFunc, path, ifNoneMatch string) *httptest.ResponseRecorder {
+		router := setupRouter()
+		router.GET("/lists/:id", handler)
+		router.GET("/tasks/:id", handler)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, tc := range []struct {
+		name    string
+		handler func(*gorm.DB) gin.HandlerFunc
+		path    string
+	}{
+		{"lista", GetList, "/lists/1"},
+		{"tarefa", GetTask, "/tasks/1"},
+	} {
+		t.Run("A "+tc.name+" vem com o ETag da versão", func(t *testing.T) {
+			w := get(tc.handler(dryRun), tc.path, "")
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, versionETag(0), w.Header().Get("ETag"))
+		})
+
+		t.Run("A "+tc.name+" responde 304 com o mesmo ETag", func(t *testing.T) {
+			w := get(tc.handler(dryRun), tc.path, versionETag(0))
+			assert.Equal(t, http.StatusNotModified, w.Code)
+		})
+
+		t.Run("A "+tc.name+" com ID inválido responde 400", func(t *testing.T) {
+			w := get(tc.handler(nil), tc.path[:len(tc.path)-1]+"abc", "")
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+
+		t.Run("A "+tc.name+" com falha no banco responde 500", func(t *testing.T) {
+			w := get(tc.handler(unreachable), tc.path, "")
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"listaPro/internal/apierror"
+	"listaPro/internal/events"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// heartbeatInterval mantém a conexão viva atrás de proxies que fecham conexões ociosas
+var heartbeatInterval = 15 * time.Second
+
+// StreamEvents (GET /api/events?lists=1,2) envia as alterações via Server-Sent Events
+func StreamEvents() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var listIDs []uint
+		if raw := c.Query("lists"); raw != "" {
+			for _, part := range strings.Split(raw, ",") {
+				id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+				if err != nil {
+					abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_list_id"))
+					return
+				}
+				listIDs = append(listIDs, uint(id))
+			}
+		}
+
+		sub := events.SubscribeStream(listIDs...)
+		defer sub.Close()
+
+		// o WriteTimeout do servidor derrubaria o stream
+		_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		c.Writer.Flush()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-c.Request.Context().Done():
+				return
+			case <-heartbeat.C:
+				fmt.Fprint(c.Writer, ": heartbeat\n\n")
+				c.Writer.Flush()
+			case event, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				event.Data = present(c, event.Data)
+				data, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+				c.Writer.Flush()
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"listaPro/internal/events"
+)
+
+func TestStreamEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Deve enviar eventos da lista assinada", func(t *testing.T) {
+		router := setupRouter()
+		router.GET("/events", StreamEvents())
+		server := httptest.NewServer(router)
+		defer server.Close()
+
+		resp, err := http.Get(server.URL + "/events?lists=7")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		// a assinatura é feita antes do primeiro flush, então já podemos publicar
+		events.Publish(events.TaskCreated, 8, nil)
+		events.Publish(events.TaskUpdated, 7, gin.H{"Text": "Oi"})
+
+		lines := make(chan string)
+		go func() {
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				lines <- scanner.Text()
+			}
+		}()
+
+		var received []string
+		timeout := time.After(2 * time.Second)
+		for len(received) < 3 {
+			select {
+			case line := <-lines:
+				received = append(received, line)
+			case <-timeout:
+				t.Fatal("evento não recebido")
+			}
+		}
+
+		assert.True(t, strings.HasPrefix(received[0], "id: "))
+		assert.Equal(t, "event: task.updated", received[1])
+		assert.Contains(t, received[2], `"listId":7`)
+	})
+
+	t.Run("Deve rejeitar IDs inválidos", func(t *testing.T) {
+		router := setupRouter()
+		router.GET("/events", StreamEvents())
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/events?lists=abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
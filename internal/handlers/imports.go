@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"io"
+	"listaPro/internal/apierror"
+	"listaPro/internal/events"
+	"listaPro/internal/i18n"
+	"listaPro/internal/importers"
+	"listaPro/internal/middleware"
+	"listaPro/internal/models"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// ImportFile (POST /api/import/:source)
+// Aceita o arquivo exportado no campo "file" (multipart) ou no corpo da requisição.
+func ImportFile(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		importer, err := importers.Get(c.Param("source"))
+		if errors.Is(err, importers.ErrUnknownSource) {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeUnknownImportSource, "unknown_import_source", strings.Join(importers.Sources(), ", ")))
+			return
+		}
+
+		opts := importers.Options{Name: c.Query("name")}
+
+		var body io.Reader = c.Request.Body
+		if file, err := c.FormFile("file"); err == nil {
+			f, err := file.Open()
+			if err != nil {
+				abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidImportFile, "invalid_file"))
+				return
+			}
+			defer f.Close()
+			body = f
+
+			if opts.Name == "" {
+				opts.Name = strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename))
+			}
+		}
+
+		result, err := importer.Import(body, opts)
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidImportFile, "invalid_import_file"))
+			return
+		}
+
+		lists := make([]models.TaskList, 0, len(result.Lists))
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, imported := range result.Lists {
+				list := models.TaskList{Name: imported.Name}
+				if list.Name == "" {
+					list.Name = i18n.T(middleware.GetLanguage(c), "default_imported_list")
+				}
+				if err := tx.Create(&list).Error; err != nil {
+					return err
+				}
+				if err := createImportedTasks(tx, list.ID, nil, imported.Tasks); err != nil {
+					return err
+				}
+				lists = append(lists, list)
+			}
+			return nil
+		})
+		if err != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "import_lists_failed"))
+			return
+		}
+
+		for i := range lists {
+			db.Preload("Tasks").First(&lists[i], lists[i].ID)
+			events.Publish(events.ListCreated, lists[i].ID, lists[i])
+		}
+
+		render(c, http.StatusCreated, gin.H{
+			"lists":    lists,
+			"unmapped": result.Unmapped,
+			"skipped":  result.Skipped,
+		})
+	}
+}
+
+// createImportedTasks grava as tarefas importadas, mantendo as subtarefas
+func createImportedTasks(tx *gorm.DB, listID uint, parentID *uint, tasks []importers.Task) error {
+	for _, imported := range tasks {
+		task := models.Task{
+			Text:        imported.Text,
+			IsCompleted: imported.Completed,
+			ListID:      listID,
+			ParentID:    parentID,
+		}
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+		if err := createImportedTasks(tx, listID, &task.ID, imported.Subtasks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
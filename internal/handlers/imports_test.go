@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportFile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Deve retornar 404 para origem desconhecida", func(t *testing.T) {
+		router := setupRouter()
+		router.POST("/import/:source", ImportFile(nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/import/asana", bytes.NewBufferString("{}"))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var response map[string]any
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "unknown_import_source", response["code"])
+		assert.Contains(t, response["detail"], "trello")
+	})
+
+	t.Run("Deve retornar 400 para arquivo inválido", func(t *testing.T) {
+		router := setupRouter()
+		router.POST("/import/:source", ImportFile(nil))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/import/trello", bytes.NewBufferString("não é json"))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
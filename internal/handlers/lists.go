@@ -1,86 +1,214 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"io"
+	"listaPro/internal/apierror"
+	"listaPro/internal/events"
 	"listaPro/internal/models"
+	"listaPro/internal/patch"
 	"net/http"
 	"strconv"
 )
 
 func GetAllLists(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		db := requestDB(c, db)
 		var lists []models.TaskList
 		if result := db.Preload("Tasks").Find(&lists); result.Error != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar " +
-				"listas"})
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_lists_failed"))
 			return
 		}
-		c.JSON(http.StatusOK, lists)
+		jsonWithETag(c, http.StatusOK, lists)
+	}
+}
+
+// GetList (GET /api/lists/:id) devolve a lista, sem as tarefas, com o ETag da
+// versão para usar no If-Match
+func GetList(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+			return
+		}
+
+		var list models.TaskList
+		result := db.First(&list, id)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
+			return
+		}
+		if result.Error != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_list_failed"))
+			return
+		}
+
+		jsonWithVersion(c, list.ChangeSeq, list)
 	}
 }
 
 func CreateList(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		db := requestDB(c, db)
 		var newList models.TaskList
 		if err := c.ShouldBindJSON(&newList); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao buscar"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
 			return
 		}
 
 		if result := db.Create(&newList); result.Error != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar"})
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "create_list_failed"))
 			return
 		}
 
-		c.JSON(http.StatusCreated, newList)
+		events.Publish(events.ListCreated, newList.ID, newList)
+		c.Header("ETag", versionETag(newList.ChangeSeq))
+		render(c, http.StatusCreated, newList)
 	}
 }
 
 func UpdateList(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		db := requestDB(c, db)
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao buscar"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
 			return
 		}
 
+		// PUT substitui a lista inteira, então o nome é obrigatório
 		var updateData struct {
-			Name string `json:"name"`
+			Name *string `json:"name"`
 		}
 		if err := c.ShouldBindJSON(&updateData); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"erro": "dados inválidos"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
+			return
+		}
+		if updateData.Name == nil || *updateData.Name == "" {
+			abort(c, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "name").WithField("name", "required"))
 			return
 		}
 
 		var list models.TaskList
 		result := db.First(&list, id)
 		if result.Error != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Lista não encontrada"})
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
 			return
 		}
 
-		list.Name = updateData.Name
-		db.Save(&list)
+		saveList(c, db, &list, map[string]interface{}{"name": *updateData.Name})
+	}
+}
+
+// PatchList (PATCH /api/lists/:id) aceita merge patch (RFC 7396) ou JSON Patch (RFC 6902)
+func PatchList(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
+			return
+		}
 
-		c.JSON(http.StatusOK, list)
+		var list models.TaskList
+		if result := db.First(&list, id); result.Error != nil {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
+			return
+		}
+
+		doc, ok := applyPatch(c, patch.Document{"name": list.Name}, body)
+		if !ok {
+			return
+		}
+
+		name, ok := doc.String("name")
+		if !ok || name == "" {
+			abort(c, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "name").WithField("name", "required"))
+			return
+		}
+
+		saveList(c, db, &list, map[string]interface{}{"name": name})
+	}
+}
+
+// saveList grava os campos respeitando o If-Match e responde com a lista atualizada
+func saveList(c *gin.Context, db *gorm.DB, list *models.TaskList, fields map[string]interface{}) {
+	ok, conditional := checkIfMatch(c, list.ChangeSeq)
+	if !ok {
+		return
+	}
+
+	// com If-Match, a escrita só acontece se ninguém alterou a lista
+	// entre a leitura e o update
+	query := db.Model(list)
+	if conditional {
+		query = query.Where("change_seq = ?", list.ChangeSeq)
 	}
+	result := query.Updates(fields)
+	if result.Error != nil {
+		abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "update_list_failed"))
+		return
+	}
+	if conditional && result.RowsAffected == 0 {
+		abort(c, apierror.New(http.StatusPreconditionFailed, apierror.CodePreconditionFailed, "precondition_failed"))
+		return
+	}
+
+	events.Publish(events.ListUpdated, list.ID, list)
+	c.Header("ETag", versionETag(list.ChangeSeq))
+	render(c, http.StatusOK, list)
 }
-//Teste!
+
+// Teste!
 func DeleteList(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		db := requestDB(c, db)
 		id, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+			return
+		}
+
+		var list models.TaskList
+		if result := db.First(&list, id); result.Error != nil {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
 			return
 		}
 
-		result := db.Delete(&models.TaskList{}, id)
+		ok, conditional := checkIfMatch(c, list.ChangeSeq)
+		if !ok {
+			return
+		}
+
+		query := db
+		if conditional {
+			query = query.Where("change_seq = ?", list.ChangeSeq)
+		}
+		result := query.Delete(&list)
+		if result.Error != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "delete_list_failed"))
+			return
+		}
 		if result.RowsAffected == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Lista não encontrada"})
+			if conditional {
+				abort(c, apierror.New(http.StatusPreconditionFailed, apierror.CodePreconditionFailed, "precondition_failed"))
+			} else {
+				abort(c, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
+			}
 			return
 		}
 
+		events.Publish(events.ListDeleted, uint(id), nil)
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"listaPro/internal/apierror"
+	"listaPro/internal/events"
+	"listaPro/internal/i18n"
+	"listaPro/internal/markdown"
+	"listaPro/internal/middleware"
+	"listaPro/internal/models"
+	"listaPro/internal/repositories"
+	"net/http"
+	"strconv"
+)
+
+// ExportListMarkdown (GET /api/lists/:id/markdown)
+func ExportListMarkdown(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		listID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+			return
+		}
+
+		list, err := repositories.NewListRepository(db).GetByID(uint(listID))
+		if err != nil {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
+			return
+		}
+
+		var buf bytes.Buffer
+		checklist := &markdown.Checklist{Title: list.Name, Items: markdown.ItemsFromTasks(list.Tasks)}
+		if err := markdown.Render(&buf, checklist); err != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "export_list_failed"))
+			return
+		}
+
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", buf.Bytes())
+	}
+}
+
+// ImportMarkdown (POST /api/lists/markdown) cria uma nova lista a partir do checklist
+func ImportMarkdown(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		checklist, err := markdown.Parse(c.Request.Body)
+		if err != nil || len(checklist.Items) == 0 {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidChecklist, "invalid_checklist"))
+			return
+		}
+
+		name := c.Query("name")
+		if name == "" {
+			name = checklist.Title
+		}
+		if name == "" {
+			name = i18n.T(middleware.GetLanguage(c), "default_imported_list")
+		}
+
+		list := models.TaskList{Name: name}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&list).Error; err != nil {
+				return err
+			}
+			return createChecklistTasks(tx, list.ID, nil, checklist.Items, nil)
+		})
+		if err != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "import_checklist_failed"))
+			return
+		}
+
+		db.Preload("Tasks").First(&list, list.ID)
+		events.Publish(events.ListCreated, list.ID, list)
+		render(c, http.StatusCreated, list)
+	}
+}
+
+// ImportMarkdownIntoList (POST /api/lists/:id/markdown) adiciona o checklist a uma lista existente
+func ImportMarkdownIntoList(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		listID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+			return
+		}
+
+		exists, err := repositories.NewListRepository(db).Exists(uint(listID))
+		if err != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_list_failed"))
+			return
+		}
+		if !exists {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
+			return
+		}
+
+		checklist, err := markdown.Parse(c.Request.Body)
+		if err != nil || len(checklist.Items) == 0 {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidChecklist, "invalid_checklist"))
+			return
+		}
+
+		var created []models.Task
+		err = db.Transaction(func(tx *gorm.DB) error {
+			return createChecklistTasks(tx, uint(listID), nil, checklist.Items, &created)
+		})
+		if err != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "import_checklist_failed"))
+			return
+		}
+
+		for _, task := range created {
+			events.Publish(events.TaskCreated, task.ListID, task)
+		}
+
+		tasks, err := repositories.NewTaskRepository(db).GetAllByList(uint(listID))
+		if err != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_tasks_failed"))
+			return
+		}
+
+		render(c, http.StatusCreated, tasks)
+	}
+}
+
+// createChecklistTasks cria as tarefas recursivamente, ligando subtarefas ao pai.
+// Se created não for nil, recebe as tarefas criadas.
+func createChecklistTasks(tx *gorm.DB, listID uint, parentID *uint, items []markdown.Item, created *[]models.Task) error {
+	for _, item := range items {
+		task := models.Task{
+			Text:        item.Text,
+			IsCompleted: item.Checked,
+			ListID:      listID,
+			ParentID:    parentID,
+		}
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+		if created != nil {
+			*created = append(*created, task)
+		}
+		if err := createChecklistTasks(tx, listID, &task.ID, item.Children, created); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"listaPro/internal/apierror"
+	"listaPro/internal/patch"
+	"net/http"
+)
+
+// applyPatch aplica o corpo da requisição ao documento conforme o Content-Type,
+// respondendo com o erro adequado quando o patch não pode ser aplicado
+func applyPatch(c *gin.Context, doc patch.Document, body []byte) (patch.Document, bool) {
+	result, err := patch.Apply(doc, c.ContentType(), body)
+	switch {
+	case err == nil:
+		return result, true
+	case errors.Is(err, patch.ErrUnsupportedMediaType):
+		c.Header("Accept-Patch", patch.MergePatchContentType+", "+patch.JSONPatchContentType)
+		abort(c, apierror.New(http.StatusUnsupportedMediaType, apierror.CodeUnsupportedMediaType, "unsupported_patch_format"))
+	case errors.Is(err, patch.ErrTestFailed):
+		abort(c, apierror.New(http.StatusConflict, apierror.CodePatchTestFailed, "patch_test_failed"))
+	case errors.Is(err, patch.ErrFieldNotAllowed):
+		abort(c, apierror.New(http.StatusUnprocessableEntity, apierror.CodeFieldNotAllowed, "field_not_allowed"))
+	default:
+		abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidPatch, "invalid_patch"))
+	}
+	return nil, false
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"listaPro/internal/patch"
+)
+
+func TestApplyPatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := setupRouter()
+	router.PATCH("/tasks/:id", func(c *gin.Context) {
+		body := new(bytes.Buffer)
+		body.ReadFrom(c.Request.Body)
+		if _, ok := applyPatch(c, patch.Document{"text": "Tarefa", "isCompleted": false}, body.Bytes()); ok {
+			c.Status(http.StatusOK)
+		}
+	})
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"merge patch", "application/merge-patch+json", `{"isCompleted": true}`, http.StatusOK},
+		{"application/json como merge patch", "application/json", `{"text": "Nova"}`, http.StatusOK},
+		{"JSON Patch", "application/json-patch+json", `[{"op": "replace", "path": "/text", "value": "Nova"}]`, http.StatusOK},
+		{"campo não permitido", "application/merge-patch+json", `{"ListID": 3}`, http.StatusUnprocessableEntity},
+		{"test falhou", "application/json-patch+json", `[{"op": "test", "path": "/text", "value": "Outra"}]`, http.StatusConflict},
+		{"formato desconhecido", "text/plain", `text=Nova`, http.StatusUnsupportedMediaType},
+		{"JSON inválido", "application/merge-patch+json", `{`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PATCH", "/tasks/1", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
+
+func TestPutRequiresFullResource(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := setupRouter()
+	router.PUT("/lists/:id", UpdateList(nil))
+	router.PUT("/tasks/:id", UpdateTask(nil))
+
+	for path, body := range map[string]string{
+		"/lists/1": `{}`,
+		"/tasks/1": `{"isCompleted": true}`,
+	} {
+		t.Run("PUT sem o campo obrigatório em "+path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			assert.Contains(t, w.Body.String(), `"code":"validation_failed"`)
+		})
+	}
+}
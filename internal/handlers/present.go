@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"listaPro/internal/dto"
+	"listaPro/internal/middleware"
+)
+
+// present adapta o corpo da resposta à versão da API: a v1 devolve os
+// models como estão e a v2 usa os DTOs em camelCase
+func present(c *gin.Context, obj any) any {
+	if middleware.GetAPIVersion(c) >= 2 {
+		return dto.From(obj)
+	}
+	return obj
+}
+
+// render responde com o JSON no formato da versão da API
+func render(c *gin.Context, status int, obj any) {
+	c.JSON(status, present(c, obj))
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"listaPro/internal/middleware"
+	"listaPro/internal/models"
+)
+
+func TestRenderByAPIVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	task := models.Task{Model: gorm.Model{ID: 1}, Text: "Leite", ListID: 2}
+
+	serve := func(version int) string {
+		router := gin.New()
+		router.GET("/task", middleware.APIVersion(version), func(c *gin.Context) {
+			render(c, http.StatusOK, task)
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/task", nil)
+		router.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	t.Run("A v1 devolve o model", func(t *testing.T) {
+		body := serve(1)
+		assert.Contains(t, body, `"ListID":2`)
+		assert.Contains(t, body, `"DeletedAt":null`)
+	})
+
+	t.Run("A v2 devolve o DTO em camelCase", func(t *testing.T) {
+		body := serve(2)
+		assert.Contains(t, body, `"listId":2`)
+		assert.Contains(t, body, `"isCompleted":false`)
+		assert.NotContains(t, body, "DeletedAt")
+	})
+}
@@ -0,0 +1,397 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"listaPro/internal/apierror"
+	"listaPro/internal/changes"
+	"listaPro/internal/events"
+	"listaPro/internal/i18n"
+	"listaPro/internal/logging"
+	"listaPro/internal/middleware"
+	"listaPro/internal/models"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+const (
+	defaultSyncLimit = 500
+	maxSyncLimit     = 2000
+	maxSyncBatch     = 500
+)
+
+type syncChanges[T any] struct {
+	Created []T    `json:"created"`
+	Updated []T    `json:"updated"`
+	Deleted []uint `json:"deleted"`
+}
+
+// present converte os itens criados e alterados para o formato da versão da API
+func (s syncChanges[T]) present(c *gin.Context) gin.H {
+	return gin.H{
+		"created": present(c, s.Created),
+		"updated": present(c, s.Updated),
+		"deleted": s.Deleted,
+	}
+}
+
+// GetChanges (GET /api/sync?since=<token>&limit=500)
+// Retorna listas e tarefas criadas, alteradas ou removidas desde o token.
+func GetChanges(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		since, err := changes.ParseToken(c.Query("since"))
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidSyncToken, "invalid_sync_token"))
+			return
+		}
+
+		limit := defaultSyncLimit
+		if raw := c.Query("limit"); raw != "" {
+			limit, err = strconv.Atoi(raw)
+			if err != nil || limit < 1 || limit > maxSyncLimit {
+				abort(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "invalid_limit").WithField("limit", "out_of_range"))
+				return
+			}
+		}
+
+		var lists []models.TaskList
+		if err := db.Unscoped().Where("change_seq > ?", since.Seq).
+			Order("change_seq").Limit(limit + 1).Find(&lists).Error; err != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_changes_failed"))
+			return
+		}
+
+		var tasks []models.Task
+		if err := db.Unscoped().Where("change_seq > ?", since.Seq).
+			Order("change_seq").Limit(limit + 1).Find(&tasks).Error; err != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_changes_failed"))
+			return
+		}
+
+		page := collectChanges(since, limit, lists, tasks)
+		c.JSON(http.StatusOK, gin.H{
+			"lists":   page.lists.present(c),
+			"tasks":   page.tasks.present(c),
+			"token":   page.token.Encode(),
+			"hasMore": page.hasMore,
+		})
+	}
+}
+
+// syncPage é uma página de alterações e o token da próxima
+type syncPage struct {
+	lists   syncChanges[models.TaskList]
+	tasks   syncChanges[models.Task]
+	token   changes.Token
+	hasMore bool
+}
+
+// collectChanges junta as duas tabelas na ordem da sequence e corta no
+// limite, de forma que o próximo token não pule nenhuma alteração. Enquanto
+// houver mais páginas, o token mantém a base do cliente, para que um
+// registro criado depois dela chegue como criado em qualquer página.
+func collectChanges(since changes.Token, limit int, lists []models.TaskList, tasks []models.Task) syncPage {
+	seqs := make([]int64, 0, len(lists)+len(tasks))
+	for _, list := range lists {
+		seqs = append(seqs, list.ChangeSeq)
+	}
+	for _, task := range tasks {
+		seqs = append(seqs, task.ChangeSeq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	page := syncPage{hasMore: len(seqs) > limit}
+	last := since.Seq
+	if page.hasMore {
+		last = seqs[limit-1]
+	} else if len(seqs) > 0 {
+		last = seqs[len(seqs)-1]
+	}
+	page.token = changes.Token{Seq: last, Base: last}
+	if page.hasMore {
+		page.token.Base = since.Base
+	}
+
+	page.lists = syncChanges[models.TaskList]{
+		Created: []models.TaskList{}, Updated: []models.TaskList{}, Deleted: []uint{},
+	}
+	for _, list := range lists {
+		if list.ChangeSeq > last {
+			continue
+		}
+		switch {
+		case list.DeletedAt.Valid:
+			page.lists.Deleted = append(page.lists.Deleted, list.ID)
+		case since.Created(list.CreatedSeq):
+			page.lists.Created = append(page.lists.Created, list)
+		default:
+			page.lists.Updated = append(page.lists.Updated, list)
+		}
+	}
+
+	page.tasks = syncChanges[models.Task]{
+		Created: []models.Task{}, Updated: []models.Task{}, Deleted: []uint{},
+	}
+	for _, task := range tasks {
+		if task.ChangeSeq > last {
+			continue
+		}
+		switch {
+		case task.DeletedAt.Valid:
+			page.tasks.Deleted = append(page.tasks.Deleted, task.ID)
+		case since.Created(task.CreatedSeq):
+			page.tasks.Created = append(page.tasks.Created, task)
+		default:
+			page.tasks.Updated = append(page.tasks.Updated, task)
+		}
+	}
+	return page
+}
+
+// syncMutation é uma alteração feita offline pelo cliente
+type syncMutation struct {
+	ClientID     string  `json:"clientId"`
+	Op           string  `json:"op"`     // create, update ou delete
+	Entity       string  `json:"entity"` // list ou task
+	ID           uint    `json:"id"`
+	ListID       uint    `json:"listId"`
+	ListClientID string  `json:"listClientId"` // lista criada no mesmo lote
+	ParentID     *uint   `json:"parentId"`
+	BaseSeq      *int64  `json:"baseSeq"` // ChangeSeq que o cliente conhecia
+	Name         *string `json:"name"`
+	Text         *string `json:"text"`
+	IsCompleted  *bool   `json:"isCompleted"`
+}
+
+type syncResult struct {
+	ClientID string `json:"clientId,omitempty"`
+	Status   string `json:"status"` // applied, conflict, not_found, invalid ou error
+	ID       uint   `json:"id,omitempty"`
+	Current  any    `json:"current,omitempty"`
+	Error    string `json:"error,omitempty"`
+
+	// err vira Error no idioma da requisição
+	err *apierror.Error
+}
+
+var (
+	errSyncConflict = errors.New("conflito")
+	errSyncInvalid  = errors.New("mutação inválida")
+)
+
+// ApplyChanges (POST /api/sync)
+// Aplica em ordem um lote de mutações offline. Cada mutação é independente:
+// uma falha não desfaz as demais. Quando baseSeq é informado e o registro
+// mudou no servidor desde então, a mutação é recusada com status "conflict"
+// e o estado atual é devolvido para o cliente reconciliar.
+func ApplyChanges(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		var batch struct {
+			Mutations []syncMutation `json:"mutations"`
+		}
+		if err := c.ShouldBindJSON(&batch); err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
+			return
+		}
+		if len(batch.Mutations) > maxSyncBatch {
+			abort(c, apierror.New(http.StatusRequestEntityTooLarge, apierror.CodePayloadTooLarge, "batch_too_large"))
+			return
+		}
+
+		createdLists := make(map[string]uint)
+		results := make([]syncResult, 0, len(batch.Mutations))
+		for _, mutation := range batch.Mutations {
+			if mutation.ListID == 0 && mutation.ListClientID != "" {
+				mutation.ListID = createdLists[mutation.ListClientID]
+			}
+
+			result := applyMutation(db, mutation)
+			if result.Status == "applied" && mutation.Entity == "list" && mutation.Op == "create" && mutation.ClientID != "" {
+				createdLists[mutation.ClientID] = result.ID
+			}
+			results = append(results, result)
+		}
+
+		lang := middleware.GetLanguage(c)
+		for i := range results {
+			results[i].Current = present(c, results[i].Current)
+			if err := results[i].err; err != nil {
+				if err.Status >= http.StatusInternalServerError {
+					logging.FromContext(c.Request.Context()).Error("sync: erro ao aplicar mutação",
+						"client_id", results[i].ClientID, logging.Err(err))
+				}
+				results[i].Error = i18n.T(lang, err.Message, err.Args...)
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{"results": results})
+	}
+}
+
+func applyMutation(db *gorm.DB, m syncMutation) syncResult {
+	result := syncResult{ClientID: m.ClientID}
+	if m.Op != "create" && m.Op != "update" && m.Op != "delete" {
+		result.Status = "invalid"
+		result.err = apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "invalid_mutation")
+		return result
+	}
+
+	var (
+		publish func()
+		current any
+	)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// o contador antes do FOR UPDATE, na mesma ordem das outras escritas
+		if err := changes.Lock(tx); err != nil {
+			return err
+		}
+		switch m.Entity {
+		case "list":
+			list, err := applyListMutation(tx, m)
+			if list != nil {
+				result.ID, current = list.ID, list
+				publish = func() { publishListMutation(m.Op, list) }
+			}
+			return err
+		case "task":
+			task, err := applyTaskMutation(tx, m)
+			if task != nil {
+				result.ID, current = task.ID, task
+				publish = func() { publishTaskMutation(m.Op, task) }
+			}
+			return err
+		default:
+			return errSyncInvalid
+		}
+	})
+
+	switch {
+	case err == nil:
+		result.Status = "applied"
+		if publish != nil {
+			publish()
+		}
+	case errors.Is(err, errSyncConflict):
+		result.Status = "conflict"
+		result.Current = current
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		result.Status = "not_found"
+	case errors.Is(err, errSyncInvalid):
+		result.Status = "invalid"
+		result.err = apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "invalid_mutation")
+	default:
+		result.Status = "error"
+		result.err = apierror.Wrap(err, http.StatusInternalServerError, apierror.CodeInternal, "apply_mutation_failed")
+	}
+	return result
+}
+
+func applyListMutation(tx *gorm.DB, m syncMutation) (*models.TaskList, error) {
+	if m.Op == "create" {
+		if m.Name == nil || *m.Name == "" {
+			return nil, errSyncInvalid
+		}
+		list := models.TaskList{Name: *m.Name}
+		return &list, tx.Create(&list).Error
+	}
+
+	var list models.TaskList
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&list, m.ID).Error; err != nil {
+		return nil, err
+	}
+	if m.BaseSeq != nil && list.ChangeSeq > *m.BaseSeq {
+		return &list, errSyncConflict
+	}
+
+	switch m.Op {
+	case "update":
+		if m.Name != nil {
+			list.Name = *m.Name
+		}
+		return &list, tx.Save(&list).Error
+	case "delete":
+		return &list, tx.Delete(&list).Error
+	default:
+		return nil, errSyncInvalid
+	}
+}
+
+func applyTaskMutation(tx *gorm.DB, m syncMutation) (*models.Task, error) {
+	if m.Op == "create" {
+		if m.Text == nil || *m.Text == "" || m.ListID == 0 {
+			return nil, errSyncInvalid
+		}
+		var list models.TaskList
+		if err := tx.First(&list, m.ListID).Error; err != nil {
+			return nil, err
+		}
+		if m.ParentID != nil {
+			// a tarefa pai precisa existir e ser da mesma lista
+			var parent models.Task
+			err := tx.First(&parent, *m.ParentID).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && parent.ListID != m.ListID) {
+				return nil, errSyncInvalid
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		task := models.Task{Text: *m.Text, ListID: m.ListID, ParentID: m.ParentID}
+		if m.IsCompleted != nil {
+			task.IsCompleted = *m.IsCompleted
+		}
+		return &task, tx.Create(&task).Error
+	}
+
+	var task models.Task
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&task, m.ID).Error; err != nil {
+		return nil, err
+	}
+	if m.BaseSeq != nil && task.ChangeSeq > *m.BaseSeq {
+		return &task, errSyncConflict
+	}
+
+	switch m.Op {
+	case "update":
+		// Updates sobre a tarefa carregada: os callbacks ainda veem a linha
+		// anterior, e o GORM copia os valores novos para task
+		fields := map[string]interface{}{}
+		if m.Text != nil {
+			fields["text"] = *m.Text
+		}
+		if m.IsCompleted != nil {
+			fields["is_completed"] = *m.IsCompleted
+		}
+		return &task, tx.Model(&task).Updates(fields).Error
+	case "delete":
+		return &task, tx.Delete(&task).Error
+	default:
+		return nil, errSyncInvalid
+	}
+}
+
+func publishListMutation(op string, list *models.TaskList) {
+	switch op {
+	case "create":
+		events.Publish(events.ListCreated, list.ID, list)
+	case "update":
+		events.Publish(events.ListUpdated, list.ID, list)
+	case "delete":
+		events.Publish(events.ListDeleted, list.ID, nil)
+	}
+}
+
+func publishTaskMutation(op string, task *models.Task) {
+	switch op {
+	case "create":
+		events.Publish(events.TaskCreated, task.ListID, task)
+	case "update":
+		events.Publish(events.TaskUpdated, task.ListID, task)
+	case "delete":
+		events.Publish(events.TaskDeleted, task.ListID, gin.H{"ID": task.ID})
+	}
+}
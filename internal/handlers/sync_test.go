@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"listaPro/internal/changes"
+	"listaPro/internal/models"
+)
+
+func TestGetChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := setupRouter()
+	router.GET("/sync", GetChanges(nil))
+
+	for _, query := range []string{"since=xyz", "limit=0", "limit=abc", "limit=99999"} {
+		t.Run("Deve rejeitar "+query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/sync?"+query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestApplyChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := setupRouter()
+	router.POST("/sync", ApplyChanges(nil))
+
+	t.Run("Deve marcar operação desconhecida como inválida", func(t *testing.T) {
+		body := `{"mutations": [{"clientId": "m1", "op": "rename", "entity": "list", "id": 1}]}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/sync", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Results []syncResult `json:"results"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Results, 1)
+		assert.Equal(t, "m1", response.Results[0].ClientID)
+		assert.Equal(t, "invalid", response.Results[0].Status)
+		assert.Equal(t, "Mutação inválida", response.Results[0].Error)
+	})
+
+	t.Run("Deve traduzir o erro da mutação", func(t *testing.T) {
+		body := `{"mutations": [{"clientId": "m1", "op": "move", "entity": "task", "id": 1}]}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/sync", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", "en")
+		router.ServeHTTP(w, req)
+
+		var response struct {
+			Results []syncResult `json:"results"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Results, 1)
+		assert.Equal(t, "Invalid mutation", response.Results[0].Error)
+	})
+
+	t.Run("Deve recusar lotes muito grandes", func(t *testing.T) {
+		mutations := make([]string, maxSyncBatch+1)
+		for i := range mutations {
+			mutations[i] = fmt.Sprintf(`{"clientId": "m%d", "op": "delete", "entity": "task", "id": %d}`, i, i+1)
+		}
+		body := `{"mutations": [` + strings.Join(mutations, ",") + `]}`
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/sync", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
+
+func TestCollectChanges(t *testing.T) {
+	list := func(id uint, seq, created int64) models.TaskList {
+		return models.TaskList{Model: gorm.Model{ID: id}, ChangeSeq: seq, CreatedSeq: created}
+	}
+	// cliente completo até 100; a lista 2 foi criada em 105 e alterada em 130
+	lists := []models.TaskList{list(1, 110, 10), list(2, 130, 105), list(3, 140, 135)}
+	since := changes.Token{Seq: 100, Base: 100}
+
+	t.Run("Corta no limite e mantém a base enquanto há mais páginas", func(t *testing.T) {
+		page := collectChanges(since, 1, lists, nil)
+
+		assert.True(t, page.hasMore)
+		assert.Equal(t, changes.Token{Seq: 110, Base: 100}, page.token)
+		require.Len(t, page.lists.Updated, 1)
+		assert.Equal(t, uint(1), page.lists.Updated[0].ID)
+		assert.Empty(t, page.lists.Created)
+	})
+
+	t.Run("Criações chegam como criadas nas páginas seguintes", func(t *testing.T) {
+		page := collectChanges(changes.Token{Seq: 110, Base: 100}, 5, lists[1:], nil)
+
+		assert.False(t, page.hasMore)
+		assert.Equal(t, changes.Token{Seq: 140, Base: 140}, page.token)
+		assert.Len(t, page.lists.Created, 2)
+		assert.Empty(t, page.lists.Updated)
+	})
+
+	t.Run("Intercala listas e tarefas pela sequence", func(t *testing.T) {
+		tasks := []models.Task{{Model: gorm.Model{ID: 7}, ListID: 1, ChangeSeq: 120, CreatedSeq: 120}}
+		page := collectChanges(since, 2, lists, tasks)
+
+		assert.Equal(t, int64(120), page.token.Seq)
+		assert.Len(t, page.lists.Updated, 1)
+		assert.Len(t, page.tasks.Created, 1)
+		assert.Empty(t, page.lists.Created, "a lista 2 (130) fica para a próxima página")
+	})
+}
+
+func TestApplyTaskMutation(t *testing.T) {
+	// em DryRun as buscas voltam vazias: a tarefa pai não é da lista 1
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{
+		DisableAutomaticPing: true, DryRun: true, SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	text, parentID := "Leite", uint(9)
+	_, err = applyTaskMutation(db, syncMutation{Op: "create", Entity: "task", ListID: 1, Text: &text, ParentID: &parentID})
+	assert.ErrorIs(t, err, errSyncInvalid)
+}
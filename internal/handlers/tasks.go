@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"io"
+	"listaPro/internal/apierror"
+	"listaPro/internal/events"
 	"listaPro/internal/models"
+	"listaPro/internal/patch"
 	"listaPro/internal/repositories"
 	"net/http"
 	"strconv"
@@ -12,9 +17,10 @@ import (
 // GetTasksByList - Obter todas as tarefas de uma lista específica
 func GetTasksByList(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		db := requestDB(c, db)
 		listID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
 			return
 		}
 
@@ -22,21 +28,47 @@ func GetTasksByList(db *gorm.DB) gin.HandlerFunc {
 
 		tasks, err := repo.GetAllByList(uint(listID))
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar tarefas"})
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_tasks_failed"))
 			return
 		}
 
-		c.JSON(http.StatusOK, tasks)
+		jsonWithETag(c, http.StatusOK, tasks)
+	}
+}
+
+// GetTask (GET /api/tasks/:id) devolve a tarefa com o ETag da versão para
+// usar no If-Match
+func GetTask(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		taskID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_task_id"))
+			return
+		}
+
+		var task models.Task
+		result := db.First(&task, taskID)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeTaskNotFound, "task_not_found"))
+			return
+		}
+		if result.Error != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_task_failed"))
+			return
+		}
+
+		jsonWithVersion(c, task.ChangeSeq, task)
 	}
 }
 
 // CreateTask (POST /api/lists/:id/tasks)
 func CreateTask(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		db := requestDB(c, db)
 		listID, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			c.JSON(http.StatusBadRequest,
-				gin.H{"error": "ID da lista inválido"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_list_id"))
 			return
 		}
 
@@ -44,8 +76,7 @@ func CreateTask(db *gorm.DB) gin.HandlerFunc {
 			Text string `json:"text"`
 		}
 		if err := c.ShouldBindJSON(&taskData); err != nil {
-			c.JSON(http.StatusBadRequest,
-				gin.H{"error": "Dados inválidos"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
 			return
 		}
 
@@ -55,68 +86,164 @@ func CreateTask(db *gorm.DB) gin.HandlerFunc {
 		}
 
 		if result := db.Create(&task); result.Error != nil {
-			c.JSON(http.StatusInternalServerError,
-				gin.H{"error": "Erro ao crear task"})
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "create_task_failed"))
 			return
 		}
 
-		c.JSON(http.StatusCreated, task)
+		events.Publish(events.TaskCreated, task.ListID, task)
+		c.Header("ETag", versionETag(task.ChangeSeq))
+		render(c, http.StatusCreated, task)
 	}
 }
 
 // UpdateTask (PUT /api/tasks/:id)
+// PUT substitui a tarefa inteira: text é obrigatório e isCompleted ausente vale false.
 func UpdateTask(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		db := requestDB(c, db)
 		taskID, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da tarefa inválido"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_task_id"))
 			return
 		}
 
 		var updateData struct {
 			Text        *string `json:"text"`
-			IsCompleted *bool   `json:"isCompleted"`
+			IsCompleted bool    `json:"isCompleted"`
 		}
 		if err := c.ShouldBindJSON(&updateData); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
+			return
+		}
+		if updateData.Text == nil || *updateData.Text == "" {
+			abort(c, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "text").WithField("text", "required"))
+			return
+		}
+
+		var task models.Task
+		if result := db.First(&task, taskID); result.Error != nil {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeTaskNotFound, "task_not_found"))
+			return
+		}
+
+		saveTask(c, db, &task, map[string]interface{}{
+			"text":         *updateData.Text,
+			"is_completed": updateData.IsCompleted,
+		})
+	}
+}
+
+// PatchTask (PATCH /api/tasks/:id) aceita merge patch (RFC 7396) ou JSON Patch (RFC 6902)
+func PatchTask(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		taskID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_task_id"))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
 			return
 		}
 
 		var task models.Task
 		if result := db.First(&task, taskID); result.Error != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Task não encontrada"})
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeTaskNotFound, "task_not_found"))
 			return
 		}
 
-		// atualiza campos fornecidos
-		if updateData.Text != nil {
-			task.Text = *updateData.Text
+		doc, ok := applyPatch(c, patch.Document{"text": task.Text, "isCompleted": task.IsCompleted}, body)
+		if !ok {
+			return
 		}
-		if updateData.IsCompleted != nil {
-			task.IsCompleted = *updateData.IsCompleted
+
+		text, ok := doc.String("text")
+		if !ok || text == "" {
+			abort(c, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_required", "text").WithField("text", "required"))
+			return
+		}
+		isCompleted, ok := doc.Bool("isCompleted")
+		if !ok {
+			abort(c, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "field_boolean", "isCompleted").WithField("isCompleted", "type_boolean"))
+			return
 		}
 
-		db.Save(&task)
+		saveTask(c, db, &task, map[string]interface{}{"text": text, "is_completed": isCompleted})
+	}
+}
+
+// saveTask grava os campos respeitando o If-Match e responde com a tarefa atualizada
+func saveTask(c *gin.Context, db *gorm.DB, task *models.Task, fields map[string]interface{}) {
+	ok, conditional := checkIfMatch(c, task.ChangeSeq)
+	if !ok {
+		return
+	}
 
-		c.JSON(http.StatusOK, task)
+	// com If-Match, a escrita só acontece se ninguém alterou a tarefa
+	// entre a leitura e o update
+	query := db.Model(task)
+	if conditional {
+		query = query.Where("change_seq = ?", task.ChangeSeq)
 	}
+	result := query.Updates(fields)
+	if result.Error != nil {
+		abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "update_task_failed"))
+		return
+	}
+	if conditional && result.RowsAffected == 0 {
+		abort(c, apierror.New(http.StatusPreconditionFailed, apierror.CodePreconditionFailed, "precondition_failed"))
+		return
+	}
+
+	events.Publish(events.TaskUpdated, task.ListID, task)
+	c.Header("ETag", versionETag(task.ChangeSeq))
+	render(c, http.StatusOK, task)
 }
 
 // DeleteTask (DELETE /api/tasks/:id)
 func DeleteTask(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		db := requestDB(c, db)
 		taskID, err := strconv.Atoi(c.Param("id"))
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da tarefa inválido"})
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_task_id"))
+			return
+		}
+
+		// carrega a tarefa antes para saber a qual lista notificar
+		var task models.Task
+		if result := db.First(&task, taskID); result.Error != nil {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeTaskNotFound, "task_not_found"))
+			return
+		}
+
+		ok, conditional := checkIfMatch(c, task.ChangeSeq)
+		if !ok {
 			return
 		}
 
-		result := db.Delete(&models.Task{}, taskID)
+		query := db
+		if conditional {
+			query = query.Where("change_seq = ?", task.ChangeSeq)
+		}
+		result := query.Delete(&task)
+		if result.Error != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "delete_task_failed"))
+			return
+		}
 		if result.RowsAffected == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Task não encontrada"})
+			if conditional {
+				abort(c, apierror.New(http.StatusPreconditionFailed, apierror.CodePreconditionFailed, "precondition_failed"))
+			} else {
+				abort(c, apierror.New(http.StatusNotFound, apierror.CodeTaskNotFound, "task_not_found"))
+			}
 			return
 		}
 
+		events.Publish(events.TaskDeleted, task.ListID, gin.H{"ID": task.ID})
 		c.Status(http.StatusNoContent)
 	}
 }
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"listaPro/internal/apierror"
+	"listaPro/internal/events"
+	"listaPro/internal/models"
+	"listaPro/internal/webhooks"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CreateWebhook (POST /api/webhooks)
+// O segredo usado na assinatura só é devolvido nesta resposta.
+func CreateWebhook(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		var webhookData struct {
+			URL    string   `json:"url"`
+			ListID *uint    `json:"listId"`
+			Events []string `json:"events"`
+			Secret string   `json:"secret"`
+		}
+		if err := c.ShouldBindJSON(&webhookData); err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
+			return
+		}
+
+		if err := webhooks.CheckURL(webhookData.URL); err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "invalid_url").WithField("url", "invalid_url"))
+			return
+		}
+
+		for _, eventType := range webhookData.Events {
+			if !slices.Contains(events.Types, eventType) {
+				abort(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "invalid_event_type", eventType).WithField("events", "invalid"))
+				return
+			}
+		}
+
+		if webhookData.ListID != nil {
+			var list models.TaskList
+			if result := db.First(&list, *webhookData.ListID); result.Error != nil {
+				abort(c, apierror.New(http.StatusNotFound, apierror.CodeListNotFound, "list_not_found"))
+				return
+			}
+		}
+
+		secret := webhookData.Secret
+		if secret == "" {
+			var err error
+			secret, err = webhooks.NewSecret()
+			if err != nil {
+				abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "generate_secret_failed"))
+				return
+			}
+		}
+
+		hook := models.Webhook{
+			URL:    webhookData.URL,
+			Secret: secret,
+			ListID: webhookData.ListID,
+			Events: strings.Join(webhookData.Events, ","),
+			Active: true,
+		}
+		if result := db.Create(&hook); result.Error != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "create_webhook_failed"))
+			return
+		}
+
+		render(c, http.StatusCreated, gin.H{"webhook": hook, "secret": secret})
+	}
+}
+
+// GetWebhooks (GET /api/webhooks)
+func GetWebhooks(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		var hooks []models.Webhook
+		if result := db.Order("id").Find(&hooks); result.Error != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_webhooks_failed"))
+			return
+		}
+		render(c, http.StatusOK, hooks)
+	}
+}
+
+// DeleteWebhook (DELETE /api/webhooks/:id)
+func DeleteWebhook(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+			return
+		}
+
+		result := db.Delete(&models.Webhook{}, id)
+		if result.Error != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "delete_webhook_failed"))
+			return
+		}
+		if result.RowsAffected == 0 {
+			abort(c, apierror.New(http.StatusNotFound, apierror.CodeWebhookNotFound, "webhook_not_found"))
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// GetWebhookDeliveries (GET /api/webhooks/:id/deliveries?status=dead)
+func GetWebhookDeliveries(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+			return
+		}
+
+		query := db.Where("webhook_id = ?", id).Order("id DESC").Limit(100)
+		if status := c.Query("status"); status != "" {
+			query = query.Where("status = ?", status)
+		}
+
+		var deliveries []models.WebhookDelivery
+		if result := query.Find(&deliveries); result.Error != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_deliveries_failed"))
+			return
+		}
+		render(c, http.StatusOK, deliveries)
+	}
+}
+
+// GetWebhookDeadLetters (GET /api/webhooks/:id/dead-letters)
+func GetWebhookDeadLetters(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := requestDB(c, db)
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidID, "invalid_id"))
+			return
+		}
+
+		var letters []models.WebhookDeadLetter
+		if result := db.Where("webhook_id = ?", id).Order("id DESC").Limit(100).Find(&letters); result.Error != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "fetch_dead_letters_failed"))
+			return
+		}
+		render(c, http.StatusOK, letters)
+	}
+}
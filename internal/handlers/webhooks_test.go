@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"listaPro/internal/apierror"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := setupRouter()
+	router.POST("/webhooks", CreateWebhook(nil))
+
+	cases := map[string]string{
+		"URL sem esquema":    `{"url": "exemplo.com/hook"}`,
+		"URL ftp":            `{"url": "ftp://exemplo.com/hook"}`,
+		"evento inexistente": `{"url": "https://exemplo.com/hook", "events": ["task.archived"]}`,
+		"JSON inválido":      `{"url": `,
+		"URL local":          `{"url": "http://localhost:8080/hook"}`,
+		"IP privado":         `{"url": "http://10.0.0.5/hook"}`,
+		"IP de metadados":    `{"url": "http://169.254.169.254/latest"}`,
+	}
+	for name, body := range cases {
+		t.Run("Deve rejeitar "+name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/webhooks", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	t.Run("Falha no banco responde 500, não 404", func(t *testing.T) {
+		// nenhum Postgres escuta nessa porta: toda consulta falha
+		db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=listapro dbname=listapro sslmode=disable connect_timeout=1"),
+			&gorm.Config{DisableAutomaticPing: true, Logger: logger.Discard})
+		require.NoError(t, err)
+
+		router := setupRouter()
+		router.DELETE("/webhooks/:id", DeleteWebhook(db))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/webhooks/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), apierror.CodeInternal)
+	})
+}
@@ -0,0 +1,152 @@
+// Package health responde às probes do Kubernetes:
+//
+//	/healthz  liveness: o processo está de pé (não consulta dependências)
+//	/readyz   readiness e startup: banco acessível, migrações aplicadas e
+//	          workers em execução
+//	/version  versão e commit do binário
+//
+// A startupProbe deve usar /readyz com um failureThreshold generoso, já
+// que as migrações rodam na subida.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"listaPro/internal/buildinfo"
+	"listaPro/internal/logging"
+	"listaPro/internal/migrations"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Check confere uma dependência; nil indica que ela está saudável
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker reúne as verificações de readiness
+type Checker struct {
+	// Timeout limita cada verificação, para a probe não ficar pendurada
+	Timeout time.Duration
+
+	mu     sync.RWMutex
+	checks []namedCheck
+}
+
+func NewChecker() *Checker {
+	return &Checker{Timeout: 2 * time.Second}
+}
+
+// Add registra uma verificação
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name, check})
+}
+
+// Result é o resultado das verificações, por nome: "ok" ou "fail". O
+// motivo da falha só vai para o log, já que /readyz é público.
+type Result struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Run executa as verificações em paralelo
+func (c *Checker) Run(ctx context.Context) (Result, bool) {
+	c.mu.RLock()
+	checks := c.checks
+	c.mu.RUnlock()
+
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, named := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+			defer cancel()
+			errs[i] = named.check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	result := Result{Status: "ok", Checks: make(map[string]string, len(checks))}
+	ok := true
+	for i, named := range checks {
+		if errs[i] != nil {
+			ok = false
+			result.Checks[named.name] = "fail"
+			logging.FromContext(ctx).Warn("health: verificação falhou", "check", named.name, logging.Err(errs[i]))
+			continue
+		}
+		result.Checks[named.name] = "ok"
+	}
+	if !ok {
+		result.Status = "unavailable"
+	}
+	return result, ok
+}
+
+// Live (GET /healthz) responde enquanto o processo consegue atender
+func Live() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	}
+}
+
+// Ready (GET /readyz) responde 503 se alguma verificação falhar
+func Ready(checker *Checker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, ok := checker.Run(c.Request.Context())
+		status := http.StatusOK
+		if !ok {
+			status = http.StatusServiceUnavailable
+		}
+		c.Header("Cache-Control", "no-store")
+		c.JSON(status, result)
+	}
+}
+
+// Version (GET /version) informa a versão e o commit do binário
+func Version() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, buildinfo.Get())
+	}
+}
+
+// Ping verifica a conexão com o banco
+func Ping(db *sql.DB) Check {
+	return db.PingContext
+}
+
+// Migrations falha enquanto houver migrações pendentes
+func Migrations(migrator *migrations.Migrator) Check {
+	return func(ctx context.Context) error {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return fmt.Errorf("%d migrações pendentes", pending)
+		}
+		return nil
+	}
+}
+
+// Running falha se o worker não estiver em execução
+func Running(running func() bool) Check {
+	return func(ctx context.Context) error {
+		if !running() {
+			return errors.New("parado")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter(checker *Checker) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/healthz", Live())
+	router.GET("/readyz", Ready(checker))
+	router.GET("/version", Version())
+	return router
+}
+
+func get(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", path, nil)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestProbes(t *testing.T) {
+	t.Run("Liveness não depende das verificações", func(t *testing.T) {
+		checker := NewChecker()
+		checker.Add("database", func(ctx context.Context) error { return errors.New("fora do ar") })
+
+		w := get(setupRouter(checker), "/healthz")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+	})
+
+	t.Run("Readiness responde 200 com tudo ok", func(t *testing.T) {
+		checker := NewChecker()
+		checker.Add("database", func(ctx context.Context) error { return nil })
+		checker.Add("webhookWorker", Running(func() bool { return true }))
+
+		w := get(setupRouter(checker), "/readyz")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status":"ok","checks":{"database":"ok","webhookWorker":"ok"}}`, w.Body.String())
+	})
+
+	t.Run("Readiness responde 503 sem o erro da verificação", func(t *testing.T) {
+		checker := NewChecker()
+		checker.Add("database", func(ctx context.Context) error { return nil })
+		checker.Add("webhookWorker", Running(func() bool { return false }))
+
+		w := get(setupRouter(checker), "/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.JSONEq(t, `{"status":"unavailable","checks":{"database":"ok","webhookWorker":"fail"}}`, w.Body.String())
+	})
+
+	t.Run("Verificação lenta estoura o timeout", func(t *testing.T) {
+		checker := NewChecker()
+		checker.Timeout = 10 * time.Millisecond
+		checker.Add("database", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		w := get(setupRouter(checker), "/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.JSONEq(t, `{"status":"unavailable","checks":{"database":"fail"}}`, w.Body.String())
+	})
+
+	t.Run("Version informa o commit", func(t *testing.T) {
+		w := get(setupRouter(NewChecker()), "/version")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var info map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		assert.Equal(t, "dev", info["version"])
+		assert.NotEmpty(t, info["commit"])
+		assert.NotEmpty(t, info["goVersion"])
+	})
+}
@@ -0,0 +1,98 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Idiomas suportados
+const (
+	PtBR = "pt-BR"
+	En   = "en"
+	Es   = "es"
+
+	Default = PtBR
+)
+
+// Supported lista os idiomas do catálogo, começando pelo padrão
+var Supported = []string{PtBR, En, Es}
+
+// Match encontra o idioma suportado para uma tag BCP 47, comparando pelo
+// idioma principal quando a região não bate (pt-PT → pt-BR, en-US → en)
+func Match(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", false
+	}
+	for _, lang := range Supported {
+		if strings.ToLower(lang) == tag {
+			return lang, true
+		}
+	}
+	primary, _, _ := strings.Cut(tag, "-")
+	for _, lang := range Supported {
+		langPrimary, _, _ := strings.Cut(strings.ToLower(lang), "-")
+		if langPrimary == primary {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate escolhe o idioma a partir do cabeçalho Accept-Language,
+// respeitando os pesos q. Sem correspondência, usa o idioma padrão.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.TrimSpace(name) == "q" {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+
+	// a ordenação estável mantém a ordem do cabeçalho entre pesos iguais
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	for _, c := range candidates {
+		if c.tag == "*" {
+			return Default
+		}
+		if lang, ok := Match(c.tag); ok {
+			return lang
+		}
+	}
+	return Default
+}
+
+// T traduz a mensagem para o idioma. Chaves desconhecidas são devolvidas
+// como estão, para que textos livres continuem funcionando.
+func T(lang, key string, args ...any) string {
+	translations, ok := catalog[key]
+	if !ok {
+		return key
+	}
+
+	message, ok := translations[lang]
+	if !ok {
+		message = translations[Default]
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
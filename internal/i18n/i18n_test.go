@@ -0,0 +1,42 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":                       PtBR,
+		"en":                     En,
+		"en-US,en;q=0.9":         En,
+		"pt-PT":                  PtBR,
+		"es-419":                 Es,
+		"fr, es;q=0.4, en;q=0.7": En,
+		"de, *;q=0.1":            PtBR,
+		"en;q=0, es":             Es,
+		"ja":                     PtBR,
+	}
+	for header, expected := range cases {
+		assert.Equal(t, expected, Negotiate(header), header)
+	}
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Task not found", T(En, "task_not_found"))
+	assert.Equal(t, "El campo text es obligatorio", T(Es, "field_required", "text"))
+	// idioma sem tradução cai no padrão
+	assert.Equal(t, "Lista não encontrada", T("fr", "list_not_found"))
+	// chave desconhecida é devolvida como texto livre
+	key := "Mensagem livre"
+	assert.Equal(t, key, T(En, key))
+}
+
+func TestCatalogComplete(t *testing.T) {
+	for key, translations := range catalog {
+		for _, lang := range Supported {
+			assert.NotEmpty(t, translations[lang], "%s sem tradução para %s", key, lang)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package i18n
+
+// catalog guarda as mensagens por chave e idioma. Toda chave precisa ter
+// pelo menos a tradução do idioma padrão.
+var catalog = map[string]map[string]string{
+	// requisição
+	"invalid_id":         {PtBR: "ID inválido", En: "Invalid ID", Es: "ID no válido"},
+	"invalid_list_id":    {PtBR: "ID da lista inválido", En: "Invalid list ID", Es: "ID de lista no válido"},
+	"invalid_task_id":    {PtBR: "ID da tarefa inválido", En: "Invalid task ID", Es: "ID de tarea no válido"},
+	"invalid_body":       {PtBR: "Dados inválidos", En: "Invalid request data", Es: "Datos no válidos"},
+	"field_required":     {PtBR: "O campo %s é obrigatório", En: "The %s field is required", Es: "El campo %s es obligatorio"},
+	"field_boolean":      {PtBR: "O campo %s deve ser booleano", En: "The %s field must be a boolean", Es: "El campo %s debe ser booleano"},
+	"field_not_allowed":  {PtBR: "Campo não pode ser alterado", En: "Field cannot be changed", Es: "El campo no se puede modificar"},
+	"invalid_url":        {PtBR: "URL inválida", En: "Invalid URL", Es: "URL no válida"},
+	"invalid_event_type": {PtBR: "Tipo de evento inválido: %s", En: "Invalid event type: %s", Es: "Tipo de evento no válido: %s"},
+	"invalid_limit":      {PtBR: "Limite inválido", En: "Invalid limit", Es: "Límite no válido"},
+	"route_not_found":    {PtBR: "Rota não encontrada", En: "Route not found", Es: "Ruta no encontrada"},
+	"method_not_allowed": {PtBR: "Método não permitido", En: "Method not allowed", Es: "Método no permitido"},
+	"rate_limited":       {PtBR: "Muitas requisições. Tente novamente em %d s", En: "Too many requests. Try again in %d s", Es: "Demasiadas solicitudes. Inténtelo de nuevo en %d s"},
+
+	// recursos
+	"list_not_found":    {PtBR: "Lista não encontrada", En: "List not found", Es: "Lista no encontrada"},
+	"task_not_found":    {PtBR: "Tarefa não encontrada", En: "Task not found", Es: "Tarea no encontrada"},
+	"webhook_not_found": {PtBR: "Webhook não encontrado", En: "Webhook not found", Es: "Webhook no encontrado"},
+
+	// concorrência e idempotência
+	"precondition_failed":      {PtBR: "Recurso modificado por outra requisição", En: "Resource was modified by another request", Es: "Recurso modificado por otra solicitud"},
+	"idempotency_key_too_long": {PtBR: "Idempotency-Key muito longa", En: "Idempotency-Key is too long", Es: "Idempotency-Key demasiado larga"},
+	"idempotency_key_reused":   {PtBR: "Idempotency-Key já usada com outra requisição", En: "Idempotency-Key was already used with a different request", Es: "Idempotency-Key ya utilizada con otra solicitud"},
+	"idempotency_in_progress":  {PtBR: "Requisição original ainda em andamento", En: "Original request is still in progress", Es: "La solicitud original aún está en curso"},
+	"idempotency_check_failed": {PtBR: "Erro ao verificar Idempotency-Key", En: "Failed to check Idempotency-Key", Es: "Error al verificar Idempotency-Key"},
+
+	// patch
+	"invalid_patch":            {PtBR: "Patch inválido", En: "Invalid patch", Es: "Patch no válido"},
+	"patch_test_failed":        {PtBR: "Operação test do JSON Patch falhou", En: "JSON Patch test operation failed", Es: "La operación test del JSON Patch falló"},
+	"unsupported_patch_format": {PtBR: "Formato de patch não suportado", En: "Unsupported patch format", Es: "Formato de patch no soportado"},
+
+	// importação e exportação
+	"invalid_checklist":       {PtBR: "Checklist inválido", En: "Invalid checklist", Es: "Checklist no válido"},
+	"invalid_file":            {PtBR: "Arquivo inválido", En: "Invalid file", Es: "Archivo no válido"},
+	"invalid_import_file":     {PtBR: "Arquivo de importação inválido", En: "Invalid import file", Es: "Archivo de importación no válido"},
+	"unknown_import_source":   {PtBR: "Origem de importação desconhecida. Origens suportadas: %s", En: "Unknown import source. Supported sources: %s", Es: "Origen de importación desconocido. Orígenes soportados: %s"},
+	"default_imported_list":   {PtBR: "Lista importada", En: "Imported list", Es: "Lista importada"},
+	"import_checklist_failed": {PtBR: "Erro ao importar checklist", En: "Failed to import checklist", Es: "Error al importar el checklist"},
+	"import_lists_failed":     {PtBR: "Erro ao importar listas", En: "Failed to import lists", Es: "Error al importar las listas"},
+	"export_list_failed":      {PtBR: "Erro ao exportar lista", En: "Failed to export list", Es: "Error al exportar la lista"},
+
+	// sincronização
+	"invalid_sync_token":    {PtBR: "Token de sincronização inválido", En: "Invalid sync token", Es: "Token de sincronización no válido"},
+	"batch_too_large":       {PtBR: "Lote de mutações muito grande", En: "Mutation batch is too large", Es: "Lote de mutaciones demasiado grande"},
+	"fetch_changes_failed":  {PtBR: "Erro ao buscar alterações", En: "Failed to fetch changes", Es: "Error al obtener los cambios"},
+	"invalid_mutation":      {PtBR: "Mutação inválida", En: "Invalid mutation", Es: "Mutación no válida"},
+	"apply_mutation_failed": {PtBR: "Erro ao aplicar mutação", En: "Failed to apply mutation", Es: "Error al aplicar la mutación"},
+
+	// erros internos
+	"internal_error":            {PtBR: "Erro interno", En: "Internal error", Es: "Error interno"},
+	"generate_response_failed":  {PtBR: "Erro ao gerar resposta", En: "Failed to build response", Es: "Error al generar la respuesta"},
+	"fetch_lists_failed":        {PtBR: "Erro ao buscar listas", En: "Failed to fetch lists", Es: "Error al obtener las listas"},
+	"fetch_list_failed":         {PtBR: "Erro ao buscar lista", En: "Failed to fetch list", Es: "Error al obtener la lista"},
+	"fetch_tasks_failed":        {PtBR: "Erro ao buscar tarefas", En: "Failed to fetch tasks", Es: "Error al obtener las tareas"},
+	"fetch_task_failed":         {PtBR: "Erro ao buscar tarefa", En: "Failed to fetch task", Es: "Error al obtener la tarea"},
+	"create_list_failed":        {PtBR: "Erro ao criar lista", En: "Failed to create list", Es: "Error al crear la lista"},
+	"create_task_failed":        {PtBR: "Erro ao criar tarefa", En: "Failed to create task", Es: "Error al crear la tarea"},
+	"update_list_failed":        {PtBR: "Erro ao atualizar lista", En: "Failed to update list", Es: "Error al actualizar la lista"},
+	"update_task_failed":        {PtBR: "Erro ao atualizar tarefa", En: "Failed to update task", Es: "Error al actualizar la tarea"},
+	"delete_list_failed":        {PtBR: "Erro ao excluir lista", En: "Failed to delete list", Es: "Error al eliminar la lista"},
+	"delete_task_failed":        {PtBR: "Erro ao excluir tarefa", En: "Failed to delete task", Es: "Error al eliminar la tarea"},
+	"fetch_webhooks_failed":     {PtBR: "Erro ao buscar webhooks", En: "Failed to fetch webhooks", Es: "Error al obtener los webhooks"},
+	"create_webhook_failed":     {PtBR: "Erro ao criar webhook", En: "Failed to create webhook", Es: "Error al crear el webhook"},
+	"delete_webhook_failed":     {PtBR: "Erro ao excluir webhook", En: "Failed to delete webhook", Es: "Error al eliminar el webhook"},
+	"generate_secret_failed":    {PtBR: "Erro ao gerar segredo", En: "Failed to generate secret", Es: "Error al generar el secreto"},
+	"fetch_deliveries_failed":   {PtBR: "Erro ao buscar entregas", En: "Failed to fetch deliveries", Es: "Error al obtener las entregas"},
+	"fetch_dead_letters_failed": {PtBR: "Erro ao buscar dead letters", En: "Failed to fetch dead letters", Es: "Error al obtener los dead letters"},
+}
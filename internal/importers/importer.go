@@ -0,0 +1,79 @@
+package importers
+
+import (
+	"errors"
+	"io"
+	"sort"
+)
+
+// ErrUnknownSource é retornado quando não existe importador para a origem pedida
+var ErrUnknownSource = errors.New("origem de importação desconhecida")
+
+// Task é uma tarefa importada, já no formato do listaPro
+type Task struct {
+	Text      string
+	Completed bool
+	Subtasks  []Task
+}
+
+// List é uma lista importada (board do Trello, projeto do Todoist, lista do To Do)
+type List struct {
+	Name  string
+	Tasks []Task
+}
+
+// Result agrupa as listas importadas e os campos da origem que não têm
+// equivalente no listaPro, com a quantidade de ocorrências de cada um
+type Result struct {
+	Lists    []List
+	Unmapped map[string]int
+	Skipped  int
+}
+
+// Options são parâmetros opcionais da importação
+type Options struct {
+	// Name é usado quando o arquivo não traz o nome da lista (ex.: CSV do Todoist)
+	Name string
+}
+
+// Importer converte um arquivo exportado por outra ferramenta
+type Importer interface {
+	Import(r io.Reader, opts Options) (*Result, error)
+}
+
+var registry = map[string]Importer{
+	"trello":       TrelloImporter{},
+	"todoist-csv":  TodoistCSVImporter{},
+	"todoist-json": TodoistJSONImporter{},
+	"mstodo":       MicrosoftToDoImporter{},
+}
+
+// Get retorna o importador registrado para a origem
+func Get(source string) (Importer, error) {
+	importer, ok := registry[source]
+	if !ok {
+		return nil, ErrUnknownSource
+	}
+	return importer, nil
+}
+
+// Sources lista as origens suportadas
+func Sources() []string {
+	sources := make([]string, 0, len(registry))
+	for source := range registry {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+func newResult() *Result {
+	return &Result{Unmapped: make(map[string]int)}
+}
+
+// unmapped registra um campo não mapeado quando ele está preenchido
+func (r *Result) unmapped(field string, present bool) {
+	if present {
+		r.Unmapped[field]++
+	}
+}
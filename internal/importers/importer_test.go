@@ -0,0 +1,115 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	_, err := Get("trello")
+	assert.NoError(t, err)
+
+	_, err = Get("asana")
+	assert.ErrorIs(t, err, ErrUnknownSource)
+}
+
+func TestTrelloImporter(t *testing.T) {
+	input := `{
+		"name": "Sprint 12",
+		"lists": [{"id": "l1", "name": "A fazer"}, {"id": "l2", "name": "Antiga", "closed": true}],
+		"cards": [
+			{"id": "c1", "name": "Deploy", "idList": "l1", "desc": "detalhes", "dueComplete": true},
+			{"id": "c2", "name": "Arquivado", "idList": "l1", "closed": true},
+			{"id": "c3", "name": "Em lista fechada", "idList": "l2"}
+		],
+		"checklists": [{"idCard": "c1", "checkItems": [
+			{"name": "Build", "state": "complete"},
+			{"name": "Smoke test", "state": "incomplete"}
+		]}]
+	}`
+
+	result, err := TrelloImporter{}.Import(strings.NewReader(input), Options{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Lists, 1)
+	assert.Equal(t, "Sprint 12", result.Lists[0].Name)
+	assert.Len(t, result.Lists[0].Tasks, 1)
+
+	task := result.Lists[0].Tasks[0]
+	assert.Equal(t, "Deploy", task.Text)
+	assert.True(t, task.Completed)
+	assert.Len(t, task.Subtasks, 2)
+	assert.True(t, task.Subtasks[0].Completed)
+	assert.False(t, task.Subtasks[1].Completed)
+
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, 1, result.Unmapped["card.desc"])
+}
+
+func TestTodoistCSVImporter(t *testing.T) {
+	input := "TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+		"section,Backend,,,,,,,,\n" +
+		"task,Migrar banco,,1,1,,,,pt,\n" +
+		"task,Criar tabelas,nota,4,2,,,,pt,\n" +
+		"task,Índices,,4,3,,,,pt,\n" +
+		"task,Revisar,,4,1,,,amanhã,pt,\n"
+
+	result, err := TodoistCSVImporter{}.Import(strings.NewReader(input), Options{Name: "Projeto"})
+	assert.NoError(t, err)
+	assert.Len(t, result.Lists, 1)
+
+	list := result.Lists[0]
+	assert.Equal(t, "Projeto", list.Name)
+	assert.Len(t, list.Tasks, 2)
+	assert.Equal(t, "Criar tabelas", list.Tasks[0].Subtasks[0].Text)
+	assert.Equal(t, "Índices", list.Tasks[0].Subtasks[0].Subtasks[0].Text)
+	assert.Equal(t, "Revisar", list.Tasks[1].Text)
+
+	assert.Equal(t, 1, result.Unmapped["section"])
+	assert.Equal(t, 1, result.Unmapped["DESCRIPTION"])
+	assert.Equal(t, 1, result.Unmapped["PRIORITY"])
+	assert.Equal(t, 1, result.Unmapped["DATE"])
+}
+
+func TestTodoistJSONImporter(t *testing.T) {
+	input := `{
+		"projects": [{"id": "10", "name": "Casa"}, {"id": 20, "name": "Velho", "is_archived": 1}],
+		"items": [
+			{"id": "1", "project_id": "10", "content": "Mercado", "checked": false},
+			{"id": "2", "project_id": "10", "parent_id": "1", "content": "Leite", "checked": 1},
+			{"id": "3", "project_id": "10", "content": "Apagada", "is_deleted": true},
+			{"id": "4", "project_id": "10", "content": "Pagar contas", "due": {"date": "2024-01-01"}}
+		]
+	}`
+
+	result, err := TodoistJSONImporter{}.Import(strings.NewReader(input), Options{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Lists, 1)
+
+	list := result.Lists[0]
+	assert.Equal(t, "Casa", list.Name)
+	assert.Len(t, list.Tasks, 2)
+	assert.Equal(t, "Leite", list.Tasks[0].Subtasks[0].Text)
+	assert.True(t, list.Tasks[0].Subtasks[0].Completed)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, 1, result.Unmapped["item.due"])
+}
+
+func TestMicrosoftToDoImporter(t *testing.T) {
+	input := `{"lists": [{"displayName": "Trabalho", "tasks": [
+		{"title": "Relatório", "status": "completed", "importance": "high"},
+		{"title": "Viagem", "status": "notStarted", "checklistItems": [{"displayName": "Passagem", "isChecked": true}]}
+	]}]}`
+
+	result, err := MicrosoftToDoImporter{}.Import(strings.NewReader(input), Options{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Lists, 1)
+
+	list := result.Lists[0]
+	assert.Equal(t, "Trabalho", list.Name)
+	assert.True(t, list.Tasks[0].Completed)
+	assert.False(t, list.Tasks[1].Completed)
+	assert.True(t, list.Tasks[1].Subtasks[0].Completed)
+	assert.Equal(t, 1, result.Unmapped["task.importance"])
+}
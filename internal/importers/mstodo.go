@@ -0,0 +1,69 @@
+package importers
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// MicrosoftToDoImporter lê o JSON das listas do Microsoft To Do no formato
+// da Microsoft Graph (todoTaskList com suas tasks e checklistItems).
+type MicrosoftToDoImporter struct{}
+
+type msTodoExport struct {
+	Lists []struct {
+		DisplayName string `json:"displayName"`
+		Tasks       []struct {
+			Title      string `json:"title"`
+			Status     string `json:"status"`
+			Importance string `json:"importance"`
+			Body       *struct {
+				Content string `json:"content"`
+			} `json:"body"`
+			DueDateTime    any      `json:"dueDateTime"`
+			ReminderDate   any      `json:"reminderDateTime"`
+			Recurrence     any      `json:"recurrence"`
+			Categories     []string `json:"categories"`
+			ChecklistItems []struct {
+				DisplayName string `json:"displayName"`
+				IsChecked   bool   `json:"isChecked"`
+			} `json:"checklistItems"`
+		} `json:"tasks"`
+	} `json:"lists"`
+}
+
+func (MicrosoftToDoImporter) Import(r io.Reader, opts Options) (*Result, error) {
+	var export msTodoExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, err
+	}
+
+	result := newResult()
+	for _, source := range export.Lists {
+		list := List{Name: source.DisplayName}
+		for _, task := range source.Tasks {
+			if task.Title == "" {
+				result.Skipped++
+				continue
+			}
+
+			result.unmapped("task.body", task.Body != nil && task.Body.Content != "")
+			result.unmapped("task.importance", task.Importance != "" && task.Importance != "normal")
+			result.unmapped("task.dueDateTime", task.DueDateTime != nil)
+			result.unmapped("task.reminderDateTime", task.ReminderDate != nil)
+			result.unmapped("task.recurrence", task.Recurrence != nil)
+			result.unmapped("task.categories", len(task.Categories) > 0)
+
+			imported := Task{Text: task.Title, Completed: task.Status == "completed"}
+			for _, item := range task.ChecklistItems {
+				imported.Subtasks = append(imported.Subtasks, Task{
+					Text:      item.DisplayName,
+					Completed: item.IsChecked,
+				})
+			}
+			list.Tasks = append(list.Tasks, imported)
+		}
+		result.Lists = append(result.Lists, list)
+	}
+
+	return result, nil
+}
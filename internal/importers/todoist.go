@@ -0,0 +1,210 @@
+package importers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TodoistCSVImporter lê o CSV de template/backup de um projeto do Todoist.
+// Cada arquivo é um projeto; o nome vem de Options.Name. A coluna INDENT
+// define as subtarefas.
+type TodoistCSVImporter struct{}
+
+func (TodoistCSVImporter) Import(r io.Reader, opts Options) (*Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+	}
+	if _, ok := columns["CONTENT"]; !ok {
+		return nil, errors.New("coluna CONTENT ausente")
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	result := newResult()
+	list := List{Name: opts.Name}
+
+	// stack[i] aponta para o slice de tarefas do nível de indentação i+1
+	stack := []*[]Task{&list.Tasks}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		kind := strings.ToLower(field(record, "TYPE"))
+		content := field(record, "CONTENT")
+		if kind != "" && kind != "task" {
+			result.unmapped(kind, content != "")
+			continue
+		}
+		if content == "" {
+			continue
+		}
+
+		result.unmapped("DESCRIPTION", field(record, "DESCRIPTION") != "")
+		result.unmapped("PRIORITY", field(record, "PRIORITY") != "" && field(record, "PRIORITY") != "4")
+		result.unmapped("RESPONSIBLE", field(record, "RESPONSIBLE") != "")
+		result.unmapped("DATE", field(record, "DATE") != "")
+
+		indent, err := strconv.Atoi(field(record, "INDENT"))
+		if err != nil || indent < 1 {
+			indent = 1
+		}
+		if indent > len(stack) {
+			indent = len(stack)
+		}
+		stack = stack[:indent]
+
+		parent := stack[indent-1]
+		*parent = append(*parent, Task{Text: content})
+		stack = append(stack, &(*parent)[len(*parent)-1].Subtasks)
+	}
+
+	result.Lists = append(result.Lists, list)
+	return result, nil
+}
+
+// TodoistJSONImporter lê o backup JSON do Todoist (formato da Sync API),
+// criando uma lista por projeto.
+type TodoistJSONImporter struct{}
+
+type todoistBackup struct {
+	Projects []struct {
+		ID       json.Number `json:"id"`
+		Name     string      `json:"name"`
+		Archived flexBool    `json:"is_archived"`
+	} `json:"projects"`
+	Items []struct {
+		ID          json.Number  `json:"id"`
+		ProjectID   json.Number  `json:"project_id"`
+		ParentID    *json.Number `json:"parent_id"`
+		Content     string       `json:"content"`
+		Description string       `json:"description"`
+		Checked     flexBool     `json:"checked"`
+		Deleted     flexBool     `json:"is_deleted"`
+		Priority    int          `json:"priority"`
+		Due         any          `json:"due"`
+		Labels      []string     `json:"labels"`
+		ChildOrder  int          `json:"child_order"`
+	} `json:"items"`
+	Sections []json.RawMessage `json:"sections"`
+	Notes    []json.RawMessage `json:"notes"`
+}
+
+func (TodoistJSONImporter) Import(r io.Reader, opts Options) (*Result, error) {
+	var backup todoistBackup
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if err := decoder.Decode(&backup); err != nil {
+		return nil, err
+	}
+
+	result := newResult()
+	result.unmapped("sections", len(backup.Sections) > 0)
+	result.unmapped("notes", len(backup.Notes) > 0)
+
+	type node struct {
+		task     Task
+		parentID string
+		project  string
+		children []string
+	}
+	nodes := make(map[string]*node)
+	var order []string
+	for _, item := range backup.Items {
+		if bool(item.Deleted) || item.Content == "" {
+			result.Skipped++
+			continue
+		}
+
+		result.unmapped("item.description", item.Description != "")
+		result.unmapped("item.priority", item.Priority > 1)
+		result.unmapped("item.due", item.Due != nil)
+		result.unmapped("item.labels", len(item.Labels) > 0)
+
+		parentID := ""
+		if item.ParentID != nil {
+			parentID = item.ParentID.String()
+		}
+		nodes[item.ID.String()] = &node{
+			task:     Task{Text: item.Content, Completed: bool(item.Checked)},
+			parentID: parentID,
+			project:  item.ProjectID.String(),
+		}
+		order = append(order, item.ID.String())
+	}
+
+	roots := make(map[string][]string)
+	for _, id := range order {
+		n := nodes[id]
+		if parent, ok := nodes[n.parentID]; ok && n.parentID != id {
+			parent.children = append(parent.children, id)
+		} else {
+			roots[n.project] = append(roots[n.project], id)
+		}
+	}
+
+	var build func(ids []string, depth int) []Task
+	build = func(ids []string, depth int) []Task {
+		tasks := make([]Task, 0, len(ids))
+		for _, id := range ids {
+			n := nodes[id]
+			task := n.task
+			// protege contra ciclos de parent_id em backups corrompidos
+			if depth < 32 {
+				task.Subtasks = build(n.children, depth+1)
+			}
+			tasks = append(tasks, task)
+		}
+		return tasks
+	}
+
+	for _, project := range backup.Projects {
+		if bool(project.Archived) {
+			result.Skipped++
+			continue
+		}
+		result.Lists = append(result.Lists, List{
+			Name:  project.Name,
+			Tasks: build(roots[project.ID.String()], 0),
+		})
+	}
+
+	return result, nil
+}
+
+// flexBool aceita tanto true/false quanto 0/1, usados por versões diferentes da API
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "null", "":
+		*b = false
+	default:
+		return errors.New("valor booleano inválido: " + string(data))
+	}
+	return nil
+}
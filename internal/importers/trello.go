@@ -0,0 +1,97 @@
+package importers
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// TrelloImporter lê o JSON exportado de um board do Trello.
+// O board vira uma lista e cada card vira uma tarefa; os itens de
+// checklist dos cards viram subtarefas.
+type TrelloImporter struct{}
+
+type trelloBoard struct {
+	Name       string            `json:"name"`
+	Lists      []trelloList      `json:"lists"`
+	Cards      []trelloCard      `json:"cards"`
+	Checklists []trelloChecklist `json:"checklists"`
+}
+
+type trelloList struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Closed bool   `json:"closed"`
+}
+
+type trelloCard struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Desc        string            `json:"desc"`
+	Closed      bool              `json:"closed"`
+	IDList      string            `json:"idList"`
+	Due         *string           `json:"due"`
+	DueComplete bool              `json:"dueComplete"`
+	Labels      []json.RawMessage `json:"labels"`
+	IDMembers   []string          `json:"idMembers"`
+	Attachments []json.RawMessage `json:"attachments"`
+}
+
+type trelloChecklist struct {
+	IDCard     string `json:"idCard"`
+	CheckItems []struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+	} `json:"checkItems"`
+}
+
+func (TrelloImporter) Import(r io.Reader, opts Options) (*Result, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, err
+	}
+
+	result := newResult()
+
+	closedLists := make(map[string]bool)
+	for _, list := range board.Lists {
+		closedLists[list.ID] = list.Closed
+	}
+	result.unmapped("list.name", len(board.Lists) > 0)
+
+	subtasks := make(map[string][]Task)
+	for _, checklist := range board.Checklists {
+		for _, item := range checklist.CheckItems {
+			subtasks[checklist.IDCard] = append(subtasks[checklist.IDCard], Task{
+				Text:      item.Name,
+				Completed: item.State == "complete",
+			})
+		}
+	}
+
+	name := board.Name
+	if name == "" {
+		name = opts.Name
+	}
+	list := List{Name: name}
+	for _, card := range board.Cards {
+		if card.Closed || closedLists[card.IDList] || card.Name == "" {
+			result.Skipped++
+			continue
+		}
+
+		result.unmapped("card.desc", card.Desc != "")
+		result.unmapped("card.due", card.Due != nil)
+		result.unmapped("card.labels", len(card.Labels) > 0)
+		result.unmapped("card.idMembers", len(card.IDMembers) > 0)
+		result.unmapped("card.attachments", len(card.Attachments) > 0)
+
+		list.Tasks = append(list.Tasks, Task{
+			Text:      card.Name,
+			Completed: card.DueComplete,
+			Subtasks:  subtasks[card.ID],
+		})
+	}
+	result.Lists = append(result.Lists, list)
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// GormLogger envia os logs do GORM para o slog, com o logger da requisição
+// quando a consulta usa db.WithContext. Consultas acima de SlowThreshold
+// viram warn; com o nível debug, todas são registradas.
+type GormLogger struct {
+	SlowThreshold time.Duration
+	level         logger.LogLevel
+}
+
+func NewGormLogger(slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{SlowThreshold: slowThreshold, level: logger.Warn}
+}
+
+func (l *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Info {
+		FromContext(ctx).InfoContext(ctx, msg, "args", args)
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Warn {
+		FromContext(ctx).WarnContext(ctx, msg, "args", args)
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Error {
+		FromContext(ctx).ErrorContext(ctx, msg, "args", args)
+	}
+}
+
+// Trace é chamado pelo GORM ao fim de cada comando SQL
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+	log := FromContext(ctx)
+	elapsed := time.Since(begin)
+
+	var (
+		level slog.Level
+		msg   string
+	)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		level, msg = slog.LevelError, "erro no banco de dados"
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold && l.level >= logger.Warn:
+		level, msg = slog.LevelWarn, "consulta lenta"
+	case log.Enabled(ctx, slog.LevelDebug):
+		level, msg = slog.LevelDebug, "consulta"
+	default:
+		return
+	}
+
+	// o SQL vem com os valores; só entra no log quando é preciso investigar
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Duration("duration", elapsed),
+	}
+	if level == slog.LevelError {
+		attrs = append(attrs, Err(err))
+	}
+	log.LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,52 @@
+// Package logging configura os logs estruturados (log/slog) e guarda no
+// contexto o logger de cada requisição, já com o request_id.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// New cria o logger no formato json (padrão) ou text, a partir do nível
+// debug, info, warn ou error
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("nível de log inválido: %q", level)
+	}
+	options := &slog.HandlerOptions{Level: lvl}
+
+	switch strings.ToLower(format) {
+	case "", "json":
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("formato de log inválido: %q (use json ou text)", format)
+	}
+}
+
+type loggerKey struct{}
+
+// WithLogger guarda o logger no contexto
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext retorna o logger da requisição ou, fora dela, o padrão
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
+
+// Err padroniza o atributo de erro
+func Err(err error) slog.Attr {
+	return slog.Any("error", err)
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "json", "warn")
+	require.NoError(t, err)
+
+	logger.Info("ignorado")
+	logger.Warn("registrado", "list_id", 7)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "registrado", entry["msg"])
+	assert.Equal(t, float64(7), entry["list_id"])
+
+	_, err = New(&buf, "xml", "info")
+	assert.Error(t, err)
+	_, err = New(&buf, "json", "verbose")
+	assert.Error(t, err)
+}
+
+func TestGormLogger(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	ctx := WithLogger(context.Background(), base.With("request_id", "req-1"))
+	gormLogger := NewGormLogger(100 * time.Millisecond)
+	sql := func() (string, int64) { return `SELECT * FROM "tasks"`, 3 }
+
+	lastEntry := func() map[string]any {
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &entry))
+		return entry
+	}
+
+	t.Run("Consulta rápida não gera log fora do debug", func(t *testing.T) {
+		buf.Reset()
+		gormLogger.Trace(ctx, time.Now(), sql, nil)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("Consulta lenta vira warn com o request_id", func(t *testing.T) {
+		buf.Reset()
+		gormLogger.Trace(ctx, time.Now().Add(-time.Second), sql, nil)
+
+		entry := lastEntry()
+		assert.Equal(t, "WARN", entry["level"])
+		assert.Equal(t, "consulta lenta", entry["msg"])
+		assert.Equal(t, "req-1", entry["request_id"])
+		assert.Equal(t, `SELECT * FROM "tasks"`, entry["sql"])
+		assert.Equal(t, float64(3), entry["rows"])
+	})
+
+	t.Run("Erro vira error, exceto registro não encontrado", func(t *testing.T) {
+		buf.Reset()
+		gormLogger.Trace(ctx, time.Now(), sql, gorm.ErrRecordNotFound)
+		assert.Empty(t, buf.String())
+
+		gormLogger.Trace(ctx, time.Now(), sql, errors.New("conexão perdida"))
+		entry := lastEntry()
+		assert.Equal(t, "ERROR", entry["level"])
+		assert.Equal(t, "conexão perdida", entry["error"])
+	})
+}
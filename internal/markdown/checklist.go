@@ -0,0 +1,119 @@
+package markdown
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Item representa um item de checklist, com seus subitens aninhados
+type Item struct {
+	Text     string
+	Checked  bool
+	Children []Item
+}
+
+// Checklist é o resultado do parse de um documento Markdown
+type Checklist struct {
+	Title string
+	Items []Item
+}
+
+var (
+	itemPattern  = regexp.MustCompile(`^([ \t]*)[-*+][ \t]+\[([ xX])\][ \t]+(.*)$`)
+	titlePattern = regexp.MustCompile(`^#[ \t]+(.+)$`)
+)
+
+// Parse lê um checklist no estilo GitHub (- [ ] / - [x]). A indentação
+// define o aninhamento; linhas que não são itens nem título são ignoradas.
+func Parse(r io.Reader) (*Checklist, error) {
+	checklist := &Checklist{}
+
+	// pilha com o nível de indentação e o caminho até o item corrente
+	type frame struct {
+		indent int
+		items  *[]Item
+	}
+	stack := []frame{{indent: -1, items: &checklist.Items}}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if checklist.Title == "" && len(checklist.Items) == 0 {
+			if m := titlePattern.FindStringSubmatch(line); m != nil {
+				checklist.Title = strings.TrimSpace(m[1])
+				continue
+			}
+		}
+
+		m := itemPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		indent := indentWidth(m[1])
+		text := strings.TrimSpace(m[3])
+		if text == "" {
+			continue
+		}
+
+		for len(stack) > 1 && indent <= stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		parent := stack[len(stack)-1].items
+		*parent = append(*parent, Item{
+			Text:    text,
+			Checked: m[2] != " ",
+		})
+		last := &(*parent)[len(*parent)-1]
+		stack = append(stack, frame{indent: indent, items: &last.Children})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return checklist, nil
+}
+
+// Render escreve o checklist em Markdown, indentando subitens com dois espaços
+func Render(w io.Writer, checklist *Checklist) error {
+	if checklist.Title != "" {
+		if _, err := fmt.Fprintf(w, "# %s\n\n", checklist.Title); err != nil {
+			return err
+		}
+	}
+	return renderItems(w, checklist.Items, 0)
+}
+
+func renderItems(w io.Writer, items []Item, depth int) error {
+	for _, item := range items {
+		mark := " "
+		if item.Checked {
+			mark = "x"
+		}
+		if _, err := fmt.Fprintf(w, "%s- [%s] %s\n", strings.Repeat("  ", depth), mark, item.Text); err != nil {
+			return err
+		}
+		if err := renderItems(w, item.Children, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indentWidth conta a indentação considerando tab como quatro espaços
+func indentWidth(prefix string) int {
+	width := 0
+	for _, r := range prefix {
+		if r == '\t' {
+			width += 4
+		} else {
+			width++
+		}
+	}
+	return width
+}
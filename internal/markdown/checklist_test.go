@@ -0,0 +1,67 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("Deve ler itens aninhados e o título", func(t *testing.T) {
+		input := strings.Join([]string{
+			"# Checklist do PR",
+			"",
+			"Descrição qualquer",
+			"- [ ] Testes",
+			"  - [x] Unitários",
+			"  - [ ] Integração",
+			"    * [X] Banco",
+			"- [x] Documentação",
+			"- item sem checkbox",
+		}, "\n")
+
+		checklist, err := Parse(strings.NewReader(input))
+		assert.NoError(t, err)
+		assert.Equal(t, "Checklist do PR", checklist.Title)
+		assert.Len(t, checklist.Items, 2)
+
+		testes := checklist.Items[0]
+		assert.Equal(t, "Testes", testes.Text)
+		assert.False(t, testes.Checked)
+		assert.Len(t, testes.Children, 2)
+		assert.True(t, testes.Children[0].Checked)
+		assert.Equal(t, "Banco", testes.Children[1].Children[0].Text)
+		assert.True(t, testes.Children[1].Children[0].Checked)
+
+		assert.Equal(t, "Documentação", checklist.Items[1].Text)
+		assert.True(t, checklist.Items[1].Checked)
+	})
+
+	t.Run("Deve aceitar tabs como indentação", func(t *testing.T) {
+		checklist, err := Parse(strings.NewReader("- [ ] Pai\n\t- [ ] Filho\n"))
+		assert.NoError(t, err)
+		assert.Len(t, checklist.Items, 1)
+		assert.Len(t, checklist.Items[0].Children, 1)
+	})
+}
+
+func TestRender(t *testing.T) {
+	checklist := &Checklist{
+		Title: "Reunião",
+		Items: []Item{
+			{Text: "Pauta", Checked: true, Children: []Item{{Text: "Ata"}}},
+			{Text: "Follow-up"},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Render(&buf, checklist))
+	assert.Equal(t, "# Reunião\n\n- [x] Pauta\n  - [ ] Ata\n- [ ] Follow-up\n", buf.String())
+
+	// ida e volta deve preservar a estrutura
+	parsed, err := Parse(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, checklist, parsed)
+}
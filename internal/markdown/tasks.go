@@ -0,0 +1,48 @@
+package markdown
+
+import (
+	"listaPro/internal/models"
+	"sort"
+)
+
+// ItemsFromTasks monta a árvore de itens a partir das tarefas da lista.
+// Subtarefas cujo pai não está na lista sobem para a raiz.
+func ItemsFromTasks(tasks []models.Task) []Item {
+	sorted := make([]models.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	known := make(map[uint]bool, len(sorted))
+	for _, task := range sorted {
+		known[task.ID] = true
+	}
+
+	children := make(map[uint][]models.Task)
+	var roots []models.Task
+	for _, task := range sorted {
+		if task.ParentID != nil && known[*task.ParentID] && *task.ParentID != task.ID {
+			children[*task.ParentID] = append(children[*task.ParentID], task)
+		} else {
+			roots = append(roots, task)
+		}
+	}
+
+	var build func(tasks []models.Task, visited map[uint]bool) []Item
+	build = func(tasks []models.Task, visited map[uint]bool) []Item {
+		items := make([]Item, 0, len(tasks))
+		for _, task := range tasks {
+			if visited[task.ID] {
+				continue
+			}
+			visited[task.ID] = true
+			items = append(items, Item{
+				Text:     task.Text,
+				Checked:  task.IsCompleted,
+				Children: build(children[task.ID], visited),
+			})
+		}
+		return items
+	}
+
+	return build(roots, make(map[uint]bool))
+}
@@ -0,0 +1,31 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"listaPro/internal/models"
+)
+
+func TestItemsFromTasks(t *testing.T) {
+	parent := uint(1)
+	orphan := uint(99)
+
+	tasks := []models.Task{
+		{Model: gorm.Model{ID: 3}, Text: "Filho", ListID: 1, ParentID: &parent, IsCompleted: true},
+		{Model: gorm.Model{ID: 1}, Text: "Pai", ListID: 1},
+		{Model: gorm.Model{ID: 2}, Text: "Outro", ListID: 1},
+		{Model: gorm.Model{ID: 4}, Text: "Órfão", ListID: 1, ParentID: &orphan},
+	}
+
+	items := ItemsFromTasks(tasks)
+
+	assert.Len(t, items, 3)
+	assert.Equal(t, "Pai", items[0].Text)
+	assert.Len(t, items[0].Children, 1)
+	assert.Equal(t, "Filho", items[0].Children[0].Text)
+	assert.True(t, items[0].Children[0].Checked)
+	assert.Equal(t, "Outro", items[1].Text)
+	assert.Equal(t, "Órfão", items[2].Text)
+}
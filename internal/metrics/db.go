@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+// RegisterDB registra o plugin do GORM, as estatísticas do pool e os
+// contadores do domínio
+func RegisterDB(db *gorm.DB) error {
+	if err := db.Use(Plugin{}); err != nil {
+		return err
+	}
+	if err := registerDomain(db); err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return register(collectors.NewDBStatsCollector(sqlDB, namespace))
+}
+
+// register ignora coletores já registrados, para RegisterDB poder ser
+// chamado de novo (ex.: nos testes)
+func register(collector prometheus.Collector) error {
+	err := Registry.Register(collector)
+	if errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		return nil
+	}
+	return err
+}
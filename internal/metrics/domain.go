@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+	"listaPro/internal/models"
+	"reflect"
+)
+
+// Os números do domínio são contados na gravação, por callbacks do GORM,
+// em vez de um COUNT(*) no banco a cada coleta. A contagem acontece depois
+// do commit da transação do comando e só se ele deu certo; dentro de uma
+// transação aberta pelo chamador (db.Transaction), o commit é do chamador,
+// e um rollback dela depois do comando ainda conta.
+var (
+	listsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lists_created_total",
+		Help:      "Listas criadas.",
+	})
+
+	tasksCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_created_total",
+		Help:      "Tarefas criadas.",
+	})
+
+	tasksCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_completed_total",
+		Help:      "Tarefas que passaram a concluídas, ao serem criadas ou atualizadas.",
+	})
+)
+
+const completingKey = "metrics:completing"
+
+var (
+	listType = reflect.TypeOf(models.TaskList{})
+	taskType = reflect.TypeOf(models.Task{})
+)
+
+// registerDomain instala os callbacks que contam listas e tarefas
+func registerDomain(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().After("gorm:commit_or_rollback_transaction").Register("metrics:count_create", countCreate),
+		callback.Update().Before("gorm:update").Register("metrics:before_count_update", checkCompleting),
+		callback.Update().After("gorm:commit_or_rollback_transaction").Register("metrics:count_update", countUpdate),
+	)
+}
+
+func countCreate(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.RowsAffected == 0 {
+		return
+	}
+	switch stmt.Schema.ModelType {
+	case listType:
+		listsCreated.Add(float64(stmt.RowsAffected))
+	case taskType:
+		tasksCreated.Add(float64(stmt.RowsAffected))
+		for _, task := range tasksOf(stmt.ReflectValue) {
+			if task.IsCompleted {
+				tasksCompleted.Inc()
+			}
+		}
+	}
+}
+
+// checkCompleting confere, antes do update, se ele conclui uma tarefa que
+// estava aberta. O estado anterior é o da tarefa que o chamador carregou e
+// passou em Model(&task).Updates(...); o GORM só copia os valores novos
+// para ela dentro de gorm:update. No Save, a tarefa já chega alterada, e
+// a conclusão não é contada.
+func checkCompleting(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.Schema.ModelType != taskType || !setsCompleted(stmt) {
+		return
+	}
+	tasks := tasksOf(stmt.ReflectValue)
+	if len(tasks) == 1 && tasks[0].ID != 0 && !tasks[0].IsCompleted {
+		db.InstanceSet(completingKey, true)
+	}
+}
+
+func countUpdate(db *gorm.DB) {
+	if db.Error != nil || db.Statement.RowsAffected == 0 {
+		return
+	}
+	if _, ok := db.InstanceGet(completingKey); ok {
+		tasksCompleted.Inc()
+	}
+}
+
+// setsCompleted diz se o update grava is_completed = true, por Updates ou
+// Update com mapa
+func setsCompleted(stmt *gorm.Statement) bool {
+	fields, ok := stmt.Dest.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	completed, _ := fields["is_completed"].(bool)
+	return completed
+}
+
+func tasksOf(value reflect.Value) []models.Task {
+	var tasks []models.Task
+	switch value = reflect.Indirect(value); value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if task, ok := reflect.Indirect(value.Index(i)).Interface().(models.Task); ok {
+				tasks = append(tasks, task)
+			}
+		}
+	case reflect.Struct:
+		if task, ok := value.Interface().(models.Task); ok {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+	"time"
+)
+
+var (
+	dbDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duração das operações do GORM, por operação e tabela.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+	}, []string{"operation", "table"})
+
+	dbErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "Operações do GORM que falharam, por operação e tabela.",
+	}, []string{"operation", "table"})
+)
+
+const startKey = "metrics:start"
+
+// Plugin é o plugin do GORM que mede cada operação (db.Use(metrics.Plugin{}))
+type Plugin struct{}
+
+func (Plugin) Name() string {
+	return "metrics"
+}
+
+// Initialize registra os callbacks antes e depois de todos os outros, para
+// medir a operação inteira, incluindo os callbacks de ChangeSeq
+func (Plugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("*").Register("metrics:before_create", before),
+		callback.Create().After("*").Register("metrics:after_create", after("create")),
+		callback.Query().Before("*").Register("metrics:before_query", before),
+		callback.Query().After("*").Register("metrics:after_query", after("query")),
+		callback.Update().Before("*").Register("metrics:before_update", before),
+		callback.Update().After("*").Register("metrics:after_update", after("update")),
+		callback.Delete().Before("*").Register("metrics:before_delete", before),
+		callback.Delete().After("*").Register("metrics:after_delete", after("delete")),
+		callback.Row().Before("*").Register("metrics:before_row", before),
+		callback.Row().After("*").Register("metrics:after_row", after("row")),
+		callback.Raw().Before("*").Register("metrics:before_raw", before),
+		callback.Raw().After("*").Register("metrics:after_raw", after("raw")),
+	)
+}
+
+func before(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		dbDuration.WithLabelValues(operation, table).Observe(time.Since(value.(time.Time)).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			dbErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package metrics expõe as métricas do Prometheus em /metrics, na porta
+// interna (ADMIN_PORT): requisições HTTP por rota, duração das consultas do
+// GORM, pool de conexões e contadores do domínio (listas e tarefas).
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"strconv"
+	"time"
+)
+
+const namespace = "listapro"
+
+// Registry guarda todas as métricas da aplicação, além das do runtime do Go
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Requisições HTTP atendidas, por método, rota e status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duração das requisições HTTP, por método e rota.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Requisições HTTP em andamento.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration, httpInFlight,
+		dbDuration, dbErrors,
+		listsCreated, tasksCreated, tasksCompleted,
+	)
+}
+
+// unmatchedRoute é o rótulo das requisições sem rota (404), para que
+// caminhos arbitrários não criem séries novas
+const unmatchedRoute = "unmatched"
+
+// HTTP mede as requisições pelo template da rota (/api/lists/:id/tasks),
+// nunca pelo caminho, que explodiria a cardinalidade
+func HTTP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		httpInFlight.Inc()
+		defer httpInFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		httpRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler (GET /metrics) serve as métricas no formato do Prometheus
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry}))
+}
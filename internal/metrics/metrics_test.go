@@ -0,0 +1,194 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"listaPro/internal/models"
+)
+
+func TestHTTP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(HTTP())
+	router.GET("/api/lists/:id/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/metrics", Handler())
+
+	for _, path := range []string{"/api/lists/1/tasks", "/api/lists/2/tasks", "/nao-existe"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+	}
+
+	t.Run("Agrupa pelo template da rota", func(t *testing.T) {
+		assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/api/lists/:id/tasks", "200")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", unmatchedRoute, "404")))
+	})
+
+	t.Run("Serve no formato do Prometheus", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/metrics", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.Contains(t, body, `listapro_http_requests_total{method="GET",route="/api/lists/:id/tasks",status="200"} 2`)
+		assert.Contains(t, body, "go_goroutines")
+		assert.NotContains(t, body, "/api/lists/1/tasks")
+	})
+}
+
+func TestPlugin(t *testing.T) {
+	// DryRun monta o SQL sem executar, mas passa pelos callbacks
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{
+		DisableAutomaticPing: true, DryRun: true, SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(Plugin{}))
+
+	var lists []models.TaskList
+	db.Find(&lists)
+	db.Create(&models.Task{Text: "Leite", ListID: 1})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(dbDuration, "listapro_db_query_duration_seconds"))
+	expected := `
+# HELP listapro_db_query_errors_total Operações do GORM que falharam, por operação e tabela.
+# TYPE listapro_db_query_errors_total counter
+`
+	assert.NoError(t, testutil.CollectAndCompare(dbErrors, strings.NewReader(expected)))
+
+	var count uint64
+	metrics, err := Registry.Gather()
+	require.NoError(t, err)
+	for _, family := range metrics {
+		if family.GetName() != "listapro_db_query_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			assert.Contains(t, []string{"query/task_lists", "create/tasks"}, labels["operation"]+"/"+labels["table"])
+			count += metric.GetHistogram().GetSampleCount()
+		}
+	}
+	assert.Equal(t, uint64(2), count)
+}
+
+func TestDomainCounters(t *testing.T) {
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{
+		DisableAutomaticPing: true, DryRun: true, SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	// statement monta o que o GORM entrega aos callbacks depois do comando
+	statement := func(model, dest any, rows int64) *gorm.DB {
+		tx := db.Model(model)
+		require.NoError(t, tx.Statement.Parse(model))
+		tx.Statement.Dest = dest
+		tx.Statement.ReflectValue = reflect.ValueOf(model)
+		tx.Statement.RowsAffected = rows
+		return tx
+	}
+
+	t.Run("Conta listas e tarefas criadas", func(t *testing.T) {
+		lists, tasks, completed := testutil.ToFloat64(listsCreated), testutil.ToFloat64(tasksCreated), testutil.ToFloat64(tasksCompleted)
+
+		list := &models.TaskList{Name: "Mercado"}
+		countCreate(statement(list, list, 1))
+		batch := &[]models.Task{{Text: "Leite"}, {Text: "Pão", IsCompleted: true}}
+		countCreate(statement(batch, batch, 2))
+
+		assert.Equal(t, lists+1, testutil.ToFloat64(listsCreated))
+		assert.Equal(t, tasks+2, testutil.ToFloat64(tasksCreated))
+		assert.Equal(t, completed+1, testutil.ToFloat64(tasksCompleted))
+	})
+
+	t.Run("Comando sem linhas afetadas não conta", func(t *testing.T) {
+		lists := testutil.ToFloat64(listsCreated)
+		list := &models.TaskList{Name: "Mercado"}
+		countCreate(statement(list, list, 0))
+		assert.Equal(t, lists, testutil.ToFloat64(listsCreated))
+	})
+
+	t.Run("Update conta só a passagem de aberta para concluída", func(t *testing.T) {
+		completed := testutil.ToFloat64(tasksCompleted)
+		task := &models.Task{Model: gorm.Model{ID: 1}}
+
+		tx := statement(task, map[string]interface{}{"is_completed": true}, 1)
+		tx.InstanceSet(completingKey, true)
+		countUpdate(tx)
+		assert.Equal(t, completed+1, testutil.ToFloat64(tasksCompleted))
+
+		// sem a marca de checkCompleting, a tarefa já estava concluída
+		countUpdate(statement(task, map[string]interface{}{"is_completed": true}, 1))
+		assert.Equal(t, completed+1, testutil.ToFloat64(tasksCompleted))
+	})
+
+	t.Run("Reconhece o update que conclui a tarefa", func(t *testing.T) {
+		open := &models.Task{Model: gorm.Model{ID: 1}}
+		done := &models.Task{Model: gorm.Model{ID: 1}, IsCompleted: true}
+
+		assert.True(t, setsCompleted(statement(open, map[string]interface{}{"is_completed": true}, 0).Statement))
+		assert.False(t, setsCompleted(statement(open, map[string]interface{}{"text": "Leite"}, 0).Statement))
+		assert.False(t, setsCompleted(statement(done, done, 0).Statement))
+	})
+
+	t.Run("Usa a tarefa carregada como estado anterior", func(t *testing.T) {
+		completing := func(task *models.Task, dest any) bool {
+			tx := statement(task, dest, 0)
+			checkCompleting(tx)
+			_, ok := tx.InstanceGet(completingKey)
+			return ok
+		}
+		conclude := map[string]interface{}{"is_completed": true}
+
+		assert.True(t, completing(&models.Task{Model: gorm.Model{ID: 1}}, conclude))
+		assert.False(t, completing(&models.Task{Model: gorm.Model{ID: 1}, IsCompleted: true}, conclude))
+		// sem a tarefa carregada, não há estado anterior
+		assert.False(t, completing(&models.Task{}, conclude))
+	})
+}
+
+func TestDomainCallbackOrder(t *testing.T) {
+	// DryRun com a transação padrão ligada, para que os callbacks de
+	// begin e commit façam parte da cadeia
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DisableAutomaticPing: true, DryRun: true, Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, registerDomain(db))
+
+	var order []string
+	probe := func(name string) func(*gorm.DB) {
+		return func(*gorm.DB) { order = append(order, name) }
+	}
+
+	t.Run("Conta depois do commit", func(t *testing.T) {
+		create, update := db.Callback().Create(), db.Callback().Update()
+		// Replace troca a função e mantém a posição do callback
+		for _, name := range []string{"gorm:begin_transaction", "gorm:create", "gorm:commit_or_rollback_transaction", "metrics:count_create"} {
+			require.NoError(t, create.Replace(name, probe(name)))
+		}
+		for _, name := range []string{"gorm:begin_transaction", "metrics:before_count_update", "gorm:update", "gorm:commit_or_rollback_transaction", "metrics:count_update"} {
+			require.NoError(t, update.Replace(name, probe(name)))
+		}
+
+		task := models.Task{Model: gorm.Model{ID: 1}, Text: "Leite"}
+		db.Create(&task)
+		assert.Equal(t, []string{"gorm:begin_transaction", "gorm:create", "gorm:commit_or_rollback_transaction", "metrics:count_create"}, order)
+
+		order = nil
+		db.Model(&task).Update("is_completed", true)
+		assert.Equal(t, []string{"gorm:begin_transaction", "metrics:before_count_update", "gorm:update", "gorm:commit_or_rollback_transaction", "metrics:count_update"}, order)
+	})
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"listaPro/internal/apierror"
+	"listaPro/internal/i18n"
+	"listaPro/internal/logging"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader é o cabeçalho usado para correlacionar requisições
+const RequestIDHeader = "X-Request-ID"
+
+const (
+	requestIDKey = "requestID"
+	languageKey  = "language"
+)
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID aceita o X-Request-ID do cliente (se for válido) ou gera um novo,
+// devolvendo-o na resposta. O contexto da requisição recebe um logger com
+// o request_id (e o trace_id, se houver trace), usado por logging.FromContext.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+
+		ctx := c.Request.Context()
+		logger := logging.FromContext(ctx).With("request_id", id)
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			logger = logger.With("trace_id", span.TraceID().String())
+		}
+		c.Request = c.Request.WithContext(logging.WithLogger(ctx, logger))
+
+		c.Next()
+	}
+}
+
+// GetRequestID retorna o ID da requisição corrente
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
+
+// Language escolhe o idioma das mensagens: o parâmetro ?lang= tem
+// prioridade sobre o cabeçalho Accept-Language
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang, ok := i18n.Match(c.Query("lang"))
+		if !ok {
+			lang = i18n.Negotiate(c.GetHeader("Accept-Language"))
+		}
+		c.Set(languageKey, lang)
+		c.Header("Content-Language", lang)
+		// Add, e não Set: o Vary pode já ter outros valores (ex.: Origin, do CORS)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// GetLanguage retorna o idioma da requisição corrente
+func GetLanguage(c *gin.Context) string {
+	if lang := c.GetString(languageKey); lang != "" {
+		return lang
+	}
+	return i18n.Default
+}
+
+// Errors converte o último erro registrado com c.Error em uma resposta
+// application/problem+json (RFC 7807). Panics viram 500 no mesmo formato.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				logging.FromContext(c.Request.Context()).Error("panic",
+					"panic", fmt.Sprint(recovered), "stack", string(debug.Stack()))
+				if !c.Writer.Written() {
+					writeProblem(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "internal_error"))
+				}
+				c.Abort()
+			}
+		}()
+
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		writeProblem(c, c.Errors.Last().Err)
+	}
+}
+
+// NotFound responde às rotas inexistentes com o envelope de erro
+func NotFound() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		abort(c, apierror.New(http.StatusNotFound, apierror.CodeRouteNotFound, "route_not_found"))
+	}
+}
+
+// MethodNotAllowed responde aos métodos não suportados com o envelope de erro
+func MethodNotAllowed() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		abort(c, apierror.New(http.StatusMethodNotAllowed, apierror.CodeMethodNotAllowed, "method_not_allowed"))
+	}
+}
+
+func writeProblem(c *gin.Context, err error) {
+	problem := apierror.ProblemFor(err, c.Request.URL.Path, GetRequestID(c), GetLanguage(c))
+	if problem.Status >= http.StatusInternalServerError {
+		logging.FromContext(c.Request.Context()).Error("erro na requisição",
+			"method", c.Request.Method, "path", c.Request.URL.Path, "status", problem.Status, logging.Err(err))
+	}
+
+	var apiErr *apierror.Error
+	if errors.As(err, &apiErr) {
+		for name, value := range apiErr.Header {
+			c.Header(name, value)
+		}
+	}
+
+	c.Render(problem.Status, problemRender{problem})
+}
+
+// problemRender escreve o JSON com o Content-Type application/problem+json
+type problemRender struct {
+	problem apierror.Problem
+}
+
+func (r problemRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return json.NewEncoder(w).Encode(r.problem)
+}
+
+func (r problemRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", apierror.ContentType)
+}
+
+func abort(c *gin.Context, err *apierror.Error) {
+	_ = c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"listaPro/internal/apierror"
+)
+
+func setupErrorsRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.Use(RequestID(), Errors())
+	router.NoRoute(NotFound())
+	router.NoMethod(MethodNotAllowed())
+	return router
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) apierror.Problem {
+	assert.Equal(t, apierror.ContentType, w.Header().Get("Content-Type"))
+	var problem apierror.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	return problem
+}
+
+func TestErrors(t *testing.T) {
+	router := setupErrorsRouter()
+	router.POST("/lists", func(c *gin.Context) {
+		abort(c, apierror.New(http.StatusUnprocessableEntity, apierror.CodeValidationFailed, "Nome obrigatório").
+			WithField("name", "required"))
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("falhou")
+	})
+	router.GET("/interno", func(c *gin.Context) {
+		_ = c.Error(errors.New("conexão recusada"))
+	})
+
+	t.Run("Deve gerar o envelope com erros de campo e request ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/lists", nil)
+		router.ServeHTTP(w, req)
+
+		problem := decodeProblem(t, w)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, apierror.CodeValidationFailed, problem.Code)
+		assert.Equal(t, []apierror.FieldError{{Field: "name", Code: "required"}}, problem.Errors)
+		assert.NotEmpty(t, problem.RequestID)
+		assert.Equal(t, problem.RequestID, w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("Panic deve virar 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/panic", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, apierror.CodeInternal, decodeProblem(t, w).Code)
+	})
+
+	t.Run("Erro comum não deve expor detalhes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/interno", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotContains(t, w.Body.String(), "conexão recusada")
+	})
+
+	t.Run("Rota e método inexistentes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/nada", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, apierror.CodeRouteNotFound, decodeProblem(t, w).Code)
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("DELETE", "/lists", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, apierror.CodeMethodNotAllowed, decodeProblem(t, w).Code)
+	})
+
+	t.Run("Request ID inválido deve ser substituído", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/nada", nil)
+		req.Header.Set(RequestIDHeader, "com espaço\n")
+		router.ServeHTTP(w, req)
+
+		assert.Len(t, w.Header().Get(RequestIDHeader), 32)
+	})
+}
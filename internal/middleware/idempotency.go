@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"listaPro/internal/apierror"
+	"listaPro/internal/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyHeader é o cabeçalho com a chave escolhida pelo cliente
+const IdempotencyHeader = "Idempotency-Key"
+
+// IdempotencyLease é quanto dura a reserva de uma chave cuja requisição
+// ainda não respondeu. Se o processo cair no meio, a chave volta a valer
+// depois disso, em vez de ficar presa até o fim do TTL. É maior que o
+// HTTP_WRITE_TIMEOUT padrão, para não liberar requisições ainda em andamento.
+const IdempotencyLease = time.Minute
+
+// IdempotencyStore persiste as chaves e respostas
+type IdempotencyStore interface {
+	Reserve(key *models.IdempotencyKey) (*models.IdempotencyKey, error)
+	Complete(key *models.IdempotencyKey) error
+	Release(key *models.IdempotencyKey) error
+}
+
+// Idempotency repete a primeira resposta de um POST quando o cliente reenvia
+// a mesma Idempotency-Key dentro do TTL. A chave é separada por cliente
+// (ClientIdentity); reutilizá-la com outro corpo ou rota retorna 409.
+func Idempotency(store IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > 255 {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "idempotency_key_too_long").WithField("Idempotency-Key", "max_length"))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abort(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidBody, "invalid_body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record := &models.IdempotencyKey{
+			Scope:       ClientIdentity(c),
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: hashBody(body),
+			ExpiresAt:   time.Now().Add(IdempotencyLease),
+		}
+
+		existing, err := store.Reserve(record)
+		if err != nil {
+			abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "idempotency_check_failed"))
+			return
+		}
+		if existing != nil {
+			replay(c, record, existing)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// erros não são memorizados: o cliente pode tentar de novo. Erros
+		// registrados com c.Error ainda não foram escritos neste ponto, pois o
+		// middleware de erros roda depois deste.
+		if len(c.Errors) > 0 || recorder.Status() >= http.StatusInternalServerError {
+			store.Release(record)
+			return
+		}
+
+		record.StatusCode = recorder.Status()
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.ETag = recorder.Header().Get("ETag")
+		record.Body = recorder.body.Bytes()
+		record.ExpiresAt = time.Now().Add(ttl)
+		if err := store.Complete(record); err != nil {
+			store.Release(record)
+		}
+	}
+}
+
+func replay(c *gin.Context, record, existing *models.IdempotencyKey) {
+	if existing.Method != record.Method || existing.Path != record.Path || existing.RequestHash != record.RequestHash {
+		abort(c, apierror.New(http.StatusConflict, apierror.CodeIdempotencyKeyReused, "idempotency_key_reused"))
+		return
+	}
+	if existing.StatusCode == 0 {
+		abort(c, apierror.New(http.StatusConflict, apierror.CodeIdempotencyInProgress, "idempotency_in_progress"))
+		return
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	if existing.ETag != "" {
+		c.Header("ETag", existing.ETag)
+	}
+	c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+	c.Abort()
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// ClientIdentity identifica quem fez a requisição. Enquanto não há contas de
+// usuário, usa o cabeçalho Authorization (como hash) ou, na falta dele, o IP.
+func ClientIdentity(c *gin.Context) string {
+	if auth := c.GetHeader("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		return "auth:" + hex.EncodeToString(sum[:16])
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// responseRecorder copia o corpo da resposta enquanto ela é escrita
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"listaPro/internal/apierror"
+	"listaPro/internal/models"
+)
+
+// memoryIdempotencyStore é um store em memória para os testes
+type memoryIdempotencyStore struct {
+	mu   sync.Mutex
+	keys map[string]models.IdempotencyKey
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{keys: make(map[string]models.IdempotencyKey)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(key *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.keys[key.Scope+key.Key]; ok && existing.ExpiresAt.After(time.Now()) {
+		return &existing, nil
+	}
+	s.keys[key.Scope+key.Key] = *key
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(key *models.IdempotencyKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key.Scope+key.Key] = *key
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(key *models.IdempotencyKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key.Scope+key.Key)
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setupStore := func() (*gin.Engine, *memoryIdempotencyStore, *int) {
+		calls := 0
+		store := newMemoryIdempotencyStore()
+		router := gin.New()
+		router.Use(Errors())
+		router.POST("/lists", Idempotency(store, time.Hour), func(c *gin.Context) {
+			calls++
+			if c.Query("falhar") != "" {
+				abort(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "Erro"))
+				return
+			}
+			c.Header("ETag", fmt.Sprintf(`"%d"`, calls))
+			c.JSON(http.StatusCreated, gin.H{"ID": calls})
+		})
+		return router, store, &calls
+	}
+	setup := func() (*gin.Engine, *int) {
+		router, _, calls := setupStore()
+		return router, calls
+	}
+
+	post := func(router *gin.Engine, path, key, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(IdempotencyHeader, key)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Deve repetir a primeira resposta", func(t *testing.T) {
+		router, calls := setup()
+
+		first := post(router, "/lists", "abc", `{"Name": "Lista"}`)
+		second := post(router, "/lists", "abc", `{"Name": "Lista"}`)
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, `"1"`, second.Header().Get("ETag"))
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("Deve retornar 409 com outro corpo", func(t *testing.T) {
+		router, calls := setup()
+
+		post(router, "/lists", "abc", `{"Name": "Lista"}`)
+		w := post(router, "/lists", "abc", `{"Name": "Outra"}`)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("Sem chave não deve memorizar", func(t *testing.T) {
+		router, calls := setup()
+
+		post(router, "/lists", "", `{}`)
+		post(router, "/lists", "", `{}`)
+
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("Erro do servidor libera a chave", func(t *testing.T) {
+		router, calls := setup()
+
+		first := post(router, "/lists?falhar=1", "abc", `{}`)
+		second := post(router, "/lists?falhar=1", "abc", `{}`)
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusInternalServerError, second.Code)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("Requisição em andamento retorna 409", func(t *testing.T) {
+		router, store, calls := setupStore()
+		store.Reserve(&models.IdempotencyKey{
+			Scope: "ip:", Key: "abc", Method: "POST", Path: "/lists", RequestHash: hashBody([]byte(`{}`)),
+			ExpiresAt: time.Now().Add(IdempotencyLease),
+		})
+
+		w := post(router, "/lists", "abc", `{}`)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, 0, *calls)
+	})
+
+	t.Run("Reserva abandonada vence com o lease, não com o TTL", func(t *testing.T) {
+		router, store, calls := setupStore()
+		// a réplica que reservou caiu antes de responder
+		store.Reserve(&models.IdempotencyKey{
+			Scope: "ip:", Key: "abc", Method: "POST", Path: "/lists", RequestHash: hashBody([]byte(`{}`)),
+			ExpiresAt: time.Now().Add(-time.Second),
+		})
+
+		w := post(router, "/lists", "abc", `{}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, *calls)
+		stored := store.keys["ip:abc"]
+		assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+	})
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"listaPro/internal/logging"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// quietPaths são chamadas o tempo todo pelo Kubernetes
+var quietPaths = map[string]bool{"/healthz": true, "/readyz": true}
+
+// AccessLog registra cada requisição com o logger da requisição (use depois
+// de RequestID). Respostas 5xx saem como error e 4xx como warn.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case quietPaths[c.Request.URL.Path]:
+			level = slog.LevelDebug
+		}
+
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).LogAttrs(ctx, level, "requisição",
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("user_agent", c.Request.UserAgent()),
+		)
+	}
+}
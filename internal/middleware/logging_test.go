@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"listaPro/internal/logging"
+)
+
+// captureLogs troca o logger padrão por um JSON em memória
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func decodeLogs(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), AccessLog())
+	router.GET("/api/lists/:id", func(c *gin.Context) {
+		logging.FromContext(c.Request.Context()).Info("buscando lista")
+		c.Status(http.StatusOK)
+	})
+	router.GET("/falha", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	t.Run("Logs da requisição levam o request_id", func(t *testing.T) {
+		buf := captureLogs(t)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/lists/7", nil)
+		req.Header.Set(RequestIDHeader, "req-123")
+		router.ServeHTTP(w, req)
+
+		entries := decodeLogs(t, buf)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "buscando lista", entries[0]["msg"])
+		assert.Equal(t, "req-123", entries[0]["request_id"])
+
+		access := entries[1]
+		assert.Equal(t, "INFO", access["level"])
+		assert.Equal(t, "req-123", access["request_id"])
+		assert.Equal(t, "/api/lists/:id", access["route"])
+		assert.Equal(t, "/api/lists/7", access["path"])
+		assert.Equal(t, float64(http.StatusOK), access["status"])
+	})
+
+	t.Run("Erros 5xx saem como ERROR", func(t *testing.T) {
+		buf := captureLogs(t)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/falha", nil)
+		router.ServeHTTP(w, req)
+
+		entries := decodeLogs(t, buf)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "ERROR", entries[0]["level"])
+		assert.Equal(t, w.Header().Get(RequestIDHeader), entries[0]["request_id"])
+	})
+}
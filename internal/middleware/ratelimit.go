@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"listaPro/internal/apierror"
+	"listaPro/internal/logging"
+	"listaPro/internal/ratelimit"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit limita as requisições de cada cliente no grupo de rotas name.
+// Toda resposta leva os cabeçalhos RateLimit-*; quando o balde esvazia, a
+// requisição recebe 429 com Retry-After. Se o store falhar, a requisição
+// passa: melhor sem limite do que fora do ar.
+//
+// O balde é do IP do cliente (c.ClientIP, que só lê o X-Forwarded-For de
+// proxies confiáveis), nunca de cabeçalhos que o cliente escolhe à vontade:
+// trocar o Authorization a cada requisição não pode render um balde novo.
+// Quando houver contas de usuário verificadas, a chave passa a ser a conta.
+func RateLimit(store ratelimit.Store, name string, limit ratelimit.Limit) gin.HandlerFunc {
+	policy := strconv.Itoa(limit.Burst) + ";w=" + strconv.Itoa(ceilSeconds(limit.Window()))
+
+	return func(c *gin.Context) {
+		result, err := store.Take(c.Request.Context(), name+":ip:"+c.ClientIP(), limit)
+		if err != nil {
+			logging.FromContext(c.Request.Context()).Warn("rate limit indisponível", "group", name, logging.Err(err))
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", policy)
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			retryAfter := max(ceilSeconds(result.RetryAfter), 1)
+			abort(c, apierror.New(http.StatusTooManyRequests, apierror.CodeRateLimited, "rate_limited", retryAfter).
+				WithHeader("Retry-After", strconv.Itoa(retryAfter)))
+			return
+		}
+		c.Next()
+	}
+}
+
+// ceilSeconds arredonda para cima, para o cliente não voltar cedo demais
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
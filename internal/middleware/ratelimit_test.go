@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"listaPro/internal/apierror"
+	"listaPro/internal/ratelimit"
+)
+
+// failingStore simula um store compartilhado fora do ar
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store indisponível")
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setup := func(store ratelimit.Store) *gin.Engine {
+		router := gin.New()
+		// como no newRouter sem TRUSTED_PROXIES
+		router.SetTrustedProxies(nil)
+		router.Use(RequestID(), Errors())
+		// 1 requisição a cada 30 segundos, rajada de 2
+		router.POST("/lists", RateLimit(store, "write", ratelimit.PerMinute(2, 2)), func(c *gin.Context) {
+			c.Status(http.StatusCreated)
+		})
+		return router
+	}
+	post := func(router *gin.Engine, ip string, headers ...string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/lists", nil)
+		req.RemoteAddr = ip + ":1234"
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Informa o limite nos cabeçalhos", func(t *testing.T) {
+		router := setup(ratelimit.NewMemoryStore())
+
+		w := post(router, "10.0.0.1")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "30", w.Header().Get("RateLimit-Reset"))
+	})
+
+	t.Run("Responde 429 com Retry-After quando o balde esvazia", func(t *testing.T) {
+		router := setup(ratelimit.NewMemoryStore())
+		post(router, "10.0.0.1")
+		post(router, "10.0.0.1")
+
+		w := post(router, "10.0.0.1")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, apierror.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "30", w.Header().Get("Retry-After"))
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+		var problem apierror.Problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, apierror.CodeRateLimited, problem.Code)
+		assert.Equal(t, "Muitas requisições. Tente novamente em 30 s", problem.Detail)
+
+		assert.Equal(t, http.StatusCreated, post(router, "10.0.0.2").Code, "outro cliente tem seu próprio balde")
+	})
+
+	t.Run("Trocar Authorization ou X-Forwarded-For não gera balde novo", func(t *testing.T) {
+		router := setup(ratelimit.NewMemoryStore())
+		for i, headers := range [][]string{
+			{"Authorization", "Bearer a", "X-Forwarded-For", "203.0.113.1"},
+			{"Authorization", "Bearer b", "X-Forwarded-For", "203.0.113.2"},
+			{"Authorization", "Bearer c", "X-Real-IP", "203.0.113.3"},
+		} {
+			w := post(router, "10.0.0.1", headers...)
+			if i < 2 {
+				assert.Equal(t, http.StatusCreated, w.Code)
+			} else {
+				assert.Equal(t, http.StatusTooManyRequests, w.Code)
+			}
+		}
+	})
+
+	t.Run("Deixa passar se o store falhar", func(t *testing.T) {
+		router := setup(failingStore{})
+
+		w := post(router, "10.0.0.1")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	})
+}
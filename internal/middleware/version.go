@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"time"
+)
+
+const apiVersionKey = "apiVersion"
+
+// APIVersion registra a versão da API do grupo de rotas, usada pelos
+// handlers para escolher o formato da resposta
+func APIVersion(version int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(apiVersionKey, version)
+		c.Next()
+	}
+}
+
+// GetAPIVersion retorna a versão da API da requisição corrente (1 por padrão)
+func GetAPIVersion(c *gin.Context) int {
+	if version := c.GetInt(apiVersionKey); version > 0 {
+		return version
+	}
+	return 1
+}
+
+// Deprecated marca as respostas como de uma versão obsoleta (RFC 9745) e
+// aponta, no Link, para a rota equivalente na versão nova
+func Deprecated(since time.Time, successor func(path string) string) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		if successor != nil {
+			c.Header("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor(c.Request.URL.Path)))
+		}
+		c.Next()
+	}
+}
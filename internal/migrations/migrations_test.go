@@ -0,0 +1,140 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "initial", migrations[0].Name)
+	assert.Contains(t, migrations[0].Up, "CREATE TABLE IF NOT EXISTS tasks")
+	assert.Contains(t, migrations[0].Down, "DROP TABLE IF EXISTS tasks")
+
+	for i := 1; i < len(migrations); i++ {
+		assert.Greater(t, migrations[i].Version, migrations[i-1].Version)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Ordena pela versão", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/0010_b.up.sql":   {Data: []byte("B")},
+			"sql/0010_b.down.sql": {Data: []byte("-B")},
+			"sql/0002_a.up.sql":   {Data: []byte("A")},
+			"sql/0002_a.down.sql": {Data: []byte("-A")},
+		}
+		migrations, err := parse(fsys, "sql")
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+		assert.Equal(t, Migration{Version: 2, Name: "a", Up: "A", Down: "-A"}, migrations[0])
+		assert.Equal(t, int64(10), migrations[1].Version)
+	})
+
+	t.Run("Exige up e down", func(t *testing.T) {
+		fsys := fstest.MapFS{"sql/0001_a.up.sql": {Data: []byte("A")}}
+		_, err := parse(fsys, "sql")
+		assert.ErrorContains(t, err, "0001_a")
+	})
+
+	t.Run("Versão repetida", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/0001_a.up.sql":   {Data: []byte("A")},
+			"sql/0001_a.down.sql": {Data: []byte("-A")},
+			"sql/0001_b.up.sql":   {Data: []byte("B")},
+			"sql/0001_b.down.sql": {Data: []byte("-B")},
+		}
+		_, err := parse(fsys, "sql")
+		assert.Error(t, err)
+	})
+
+	t.Run("Nome inválido", func(t *testing.T) {
+		fsys := fstest.MapFS{"sql/initial.sql": {Data: []byte("A")}}
+		_, err := parse(fsys, "sql")
+		assert.Error(t, err)
+	})
+}
+
+// baselineSchema é o que o AutoMigrate criava antes das migrações, com os
+// modelos sem ChangeSeq e ParentID
+const baselineSchema = `
+CREATE TABLE task_lists (
+    id bigserial, created_at timestamptz, updated_at timestamptz, deleted_at timestamptz,
+    name text NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_task_lists_deleted_at ON task_lists (deleted_at);
+CREATE TABLE tasks (
+    id bigserial, created_at timestamptz, updated_at timestamptz, deleted_at timestamptz,
+    text text NOT NULL, is_completed boolean DEFAULT false, list_id bigint NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_task_lists_tasks FOREIGN KEY (list_id) REFERENCES task_lists (id)
+);
+CREATE INDEX idx_tasks_deleted_at ON tasks (deleted_at);
+INSERT INTO task_lists (name) VALUES ('Mercado');
+INSERT INTO tasks (text, list_id) VALUES ('Leite', 1), ('Pão', 1);
+`
+
+// TestUpFromBaseline roda as migrações sobre um banco criado pelo
+// AutoMigrate antigo. Precisa de um Postgres em TEST_DATABASE_URL (DSN no
+// formato chave=valor); o teste usa um schema próprio e o remove no fim.
+func TestUpFromBaseline(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL não definido")
+	}
+	ctx := context.Background()
+
+	admin, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	defer admin.Close()
+
+	schema := fmt.Sprintf("baseline_%d", time.Now().UnixNano())
+	_, err = admin.ExecContext(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	defer admin.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE")
+
+	db, err := sql.Open("pgx", dsn+" search_path="+schema)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.ExecContext(ctx, baselineSchema)
+	require.NoError(t, err)
+
+	migrator, err := New(db)
+	require.NoError(t, err)
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrator.migrations))
+
+	t.Run("Registros antigos recebem change_seq", func(t *testing.T) {
+		var missing int
+		require.NoError(t, db.QueryRowContext(ctx,
+			"SELECT (SELECT count(*) FROM task_lists WHERE change_seq IS NULL OR change_seq = 0) + (SELECT count(*) FROM tasks WHERE change_seq IS NULL OR change_seq = 0)",
+		).Scan(&missing))
+		assert.Zero(t, missing)
+	})
+
+	t.Run("Tarefas ganham parent_id com a chave estrangeira validada", func(t *testing.T) {
+		var validated bool
+		require.NoError(t, db.QueryRowContext(ctx,
+			"SELECT convalidated FROM pg_constraint WHERE conname = 'fk_tasks_parent' AND connamespace = $1::regnamespace", schema,
+		).Scan(&validated))
+		assert.True(t, validated)
+
+		_, err := db.ExecContext(ctx, "UPDATE tasks SET parent_id = 1 WHERE id = 2")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS webhook_dead_letters;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+DROP TABLE IF EXISTS tasks;
+DROP TABLE IF EXISTS task_lists;
+DROP SEQUENCE IF EXISTS change_seq;
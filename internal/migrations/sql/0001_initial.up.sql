@@ -0,0 +1,114 @@
+-- Esquema criado até então pelo AutoMigrate. Usa IF NOT EXISTS para que
+-- bancos já existentes adotem as migrações sem recriar nada. Bancos do
+-- AutoMigrate de antes do sync e das subtarefas não têm change_seq nem
+-- parent_id: as colunas entram antes dos índices e do backfill.
+
+CREATE SEQUENCE IF NOT EXISTS change_seq;
+
+CREATE TABLE IF NOT EXISTS task_lists (
+    id         bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name       text NOT NULL,
+    change_seq bigint
+);
+ALTER TABLE task_lists ADD COLUMN IF NOT EXISTS change_seq bigint;
+CREATE INDEX IF NOT EXISTS idx_task_lists_deleted_at ON task_lists (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_task_lists_change_seq ON task_lists (change_seq);
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id           bigserial PRIMARY KEY,
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    deleted_at   timestamptz,
+    text         text NOT NULL,
+    is_completed boolean DEFAULT false,
+    list_id      bigint NOT NULL,
+    parent_id    bigint,
+    change_seq   bigint
+);
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS parent_id bigint,
+    ADD COLUMN IF NOT EXISTS change_seq bigint;
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_change_seq ON tasks (change_seq);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_task_lists_tasks') THEN
+        ALTER TABLE tasks ADD CONSTRAINT fk_task_lists_tasks
+            FOREIGN KEY (list_id) REFERENCES task_lists (id);
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id         bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    url        text NOT NULL,
+    secret     text NOT NULL,
+    list_id    bigint,
+    events     text,
+    active     boolean DEFAULT true
+);
+CREATE INDEX IF NOT EXISTS idx_webhooks_deleted_at ON webhooks (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_list_id ON webhooks (list_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              bigserial PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    deleted_at      timestamptz,
+    webhook_id      bigint NOT NULL,
+    event_type      text NOT NULL,
+    payload         text NOT NULL,
+    status          text NOT NULL DEFAULT 'pending',
+    attempts        bigint DEFAULT 0,
+    next_attempt_at timestamptz,
+    response_status bigint,
+    last_error      text,
+    delivered_at    timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_deleted_at ON webhook_deliveries (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries (next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+    id          bigserial PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    webhook_id  bigint NOT NULL,
+    delivery_id bigint NOT NULL,
+    event_type  text NOT NULL,
+    payload     text NOT NULL,
+    attempts    bigint,
+    last_error  text
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_dead_letters_deleted_at ON webhook_dead_letters (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_dead_letters_webhook_id ON webhook_dead_letters (webhook_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_dead_letters_delivery_id ON webhook_dead_letters (delivery_id);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id           bigserial PRIMARY KEY,
+    scope        text NOT NULL,
+    key          text NOT NULL,
+    method       text NOT NULL,
+    path         text NOT NULL,
+    request_hash text NOT NULL,
+    status_code  bigint,
+    content_type text,
+    body         bytea,
+    created_at   timestamptz,
+    expires_at   timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_scope_key ON idempotency_keys (scope, key);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+-- registros antigos entram na primeira sincronização
+UPDATE task_lists SET change_seq = nextval('change_seq') WHERE change_seq IS NULL OR change_seq = 0;
+UPDATE tasks SET change_seq = nextval('change_seq') WHERE change_seq IS NULL OR change_seq = 0;
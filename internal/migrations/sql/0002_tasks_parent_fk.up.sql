@@ -0,0 +1,5 @@
+-- Subtarefas passam a exigir uma tarefa pai existente. NOT VALID não varre
+-- a tabela, então o lock forte do ADD CONSTRAINT dura pouco; a validação
+-- das linhas existentes fica para a 0004, em outra transação.
+ALTER TABLE tasks ADD CONSTRAINT fk_tasks_parent
+    FOREIGN KEY (parent_id) REFERENCES tasks (id) NOT VALID;
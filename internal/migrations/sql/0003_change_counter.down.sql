@@ -0,0 +1,6 @@
+ALTER TABLE tasks DROP COLUMN created_seq;
+ALTER TABLE task_lists DROP COLUMN created_seq;
+
+CREATE SEQUENCE change_seq;
+SELECT setval('change_seq', seq) FROM change_counter WHERE seq > 0;
+DROP TABLE change_counter;
@@ -0,0 +1,21 @@
+-- A sequence numerava as alterações fora da transação: uma transação lenta,
+-- com número menor, podia confirmar depois que o sync já tinha passado dele,
+-- e a alteração nunca era entregue. O contador fica em uma linha, atualizada
+-- dentro da transação da escrita; o lock da linha faz as escritas
+-- confirmarem na ordem dos números.
+CREATE TABLE change_counter (
+    id  integer PRIMARY KEY CHECK (id = 1),
+    seq bigint NOT NULL
+);
+INSERT INTO change_counter (id, seq)
+SELECT 1, GREATEST(
+    (SELECT last_value FROM change_seq),
+    (SELECT COALESCE(MAX(change_seq), 0) FROM task_lists),
+    (SELECT COALESCE(MAX(change_seq), 0) FROM tasks)
+);
+DROP SEQUENCE change_seq;
+
+-- número da alteração que criou o registro, para o sync separar criados de
+-- alterados; os registros antigos ficam com 0 e chegam como alterados
+ALTER TABLE task_lists ADD COLUMN created_seq bigint NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN created_seq bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Não há como desfazer só a validação: recria a constraint sem validar,
+-- como a 0002 deixou.
+ALTER TABLE tasks DROP CONSTRAINT fk_tasks_parent;
+ALTER TABLE tasks ADD CONSTRAINT fk_tasks_parent
+    FOREIGN KEY (parent_id) REFERENCES tasks (id) NOT VALID;
@@ -0,0 +1,4 @@
+-- Valida as linhas existentes contra a fk_tasks_parent (0002). Em uma
+-- transação separada, o VALIDATE só pega SHARE UPDATE EXCLUSIVE em tasks:
+-- leituras e escritas continuam durante a varredura.
+ALTER TABLE tasks VALIDATE CONSTRAINT fk_tasks_parent;
@@ -0,0 +1,2 @@
+-- ETag da resposta original, repetido junto com ela
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS etag text;
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// IdempotencyKey guarda a primeira resposta de um POST com Idempotency-Key,
+// para ser repetida quando o cliente reenviar a mesma requisição
+type IdempotencyKey struct {
+	ID          uint   `gorm:"primarykey"`
+	Scope       string `gorm:"not null;uniqueIndex:idx_idempotency_scope_key"`
+	Key         string `gorm:"not null;uniqueIndex:idx_idempotency_scope_key"`
+	Method      string `gorm:"not null"`
+	Path        string `gorm:"not null"`
+	RequestHash string `gorm:"not null"`
+	StatusCode  int    // zero enquanto a requisição original está em andamento
+	ContentType string
+	ETag        string `gorm:"column:etag"`
+	Body        []byte
+	CreatedAt   time.Time
+	// enquanto a requisição original está em andamento, é o fim da reserva
+	// (lease); com a resposta gravada, passa a ser o fim do TTL
+	ExpiresAt time.Time `gorm:"index"`
+}
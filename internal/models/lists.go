@@ -4,6 +4,9 @@ import "gorm.io/gorm"
 
 type TaskList struct {
 	gorm.Model
-	Name  string `gorm:"not null"`
-	Tasks []Task `gorm:"foreignkey:ListID"`
+	Name      string `gorm:"not null"`
+	Tasks     []Task `gorm:"foreignkey:ListID"`
+	ChangeSeq int64  `gorm:"index"`
+	// CreatedSeq é o ChangeSeq da criação, usado pelo sync
+	CreatedSeq int64 `json:"-"`
 }
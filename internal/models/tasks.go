@@ -7,4 +7,8 @@ type Task struct {
 	Text        string `gorm:"not null"`
 	IsCompleted bool   `gorm:"default:false"`
 	ListID      uint   `gorm:"not null"`
+	ParentID    *uint  `gorm:"index"`
+	ChangeSeq   int64  `gorm:"index"`
+	// CreatedSeq é o ChangeSeq da criação, usado pelo sync
+	CreatedSeq int64 `json:"-"`
 }
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook é uma URL notificada a cada alteração. Sem ListID, recebe os
+// eventos de todas as listas.
+type Webhook struct {
+	gorm.Model
+	URL    string `gorm:"not null"`
+	Secret string `gorm:"not null" json:"-"`
+	ListID *uint  `gorm:"index"`
+	Events string // tipos de evento separados por vírgula; vazio = todos
+	Active bool   `gorm:"default:true"`
+}
+
+// Status de uma entrega de webhook
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// WebhookDelivery é uma entrega de evento, com o histórico da última tentativa
+type WebhookDelivery struct {
+	gorm.Model
+	WebhookID      uint      `gorm:"not null;index"`
+	EventType      string    `gorm:"not null"`
+	Payload        string    `gorm:"type:text;not null"`
+	Status         string    `gorm:"not null;default:pending;index"`
+	Attempts       int       `gorm:"default:0"`
+	NextAttemptAt  time.Time `gorm:"index"`
+	ResponseStatus int
+	LastError      string
+	DeliveredAt    *time.Time
+}
+
+// WebhookDeadLetter guarda as entregas que esgotaram as tentativas
+type WebhookDeadLetter struct {
+	gorm.Model
+	WebhookID  uint   `gorm:"not null;index"`
+	DeliveryID uint   `gorm:"not null;uniqueIndex"`
+	EventType  string `gorm:"not null"`
+	Payload    string `gorm:"type:text;not null"`
+	Attempts   int
+	LastError  string
+}
@@ -0,0 +1,94 @@
+// Package openapi serve a especificação OpenAPI 3.1 da API e o Swagger UI.
+// Os arquivos do Swagger UI vêm embutidos no binário (swaggo/files), com a
+// versão e o hash fixados no go.sum, em vez de carregados de um CDN.
+// O documento é mantido à mão em openapi.json; o teste do pacote routes
+// falha se as rotas registradas e o documento divergirem.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+//go:embed swagger.html
+var swaggerUI []byte
+
+// Operation é um par método + caminho descrito no documento
+type Operation struct {
+	Method string
+	Path   string
+}
+
+var methods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+// Spec (GET /api/openapi.json) devolve o documento OpenAPI
+func Spec() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+	}
+}
+
+// UI (GET /api/docs) devolve o Swagger UI apontando para /api/openapi.json
+func UI() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", swaggerUI)
+	}
+}
+
+// Asset (GET /api/docs/:file) devolve os arquivos do Swagger UI
+func Asset() gin.HandlerFunc {
+	assets := http.FS(swaggerFiles.FS)
+	return func(c *gin.Context) {
+		c.FileFromFS(c.Param("file"), assets)
+	}
+}
+
+// Operations lista as operações descritas no documento, com os caminhos
+// no formato do Gin (/lists/:id em vez de /lists/{id})
+func Operations() ([]Operation, error) {
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+
+	var ops []Operation
+	for path, item := range doc.Paths {
+		for method := range item {
+			if !methods[method] {
+				continue
+			}
+			ops = append(ops, Operation{Method: strings.ToUpper(method), Path: GinPath(path)})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path == ops[j].Path {
+			return ops[i].Method < ops[j].Method
+		}
+		return ops[i].Path < ops[j].Path
+	})
+	return ops, nil
+}
+
+// GinPath converte os parâmetros de caminho do OpenAPI ({id}) para o Gin (:id)
+func GinPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			parts[i] = ":" + part[1:len(part)-1]
+		}
+	}
+	return strings.Join(parts, "/")
+}
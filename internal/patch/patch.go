@@ -0,0 +1,185 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+var (
+	ErrInvalidPatch    = errors.New("patch inválido")
+	ErrFieldNotAllowed = errors.New("campo não permitido")
+	ErrTestFailed      = errors.New("operação test falhou")
+	// ErrUnsupportedMediaType indica um Content-Type de patch desconhecido
+	ErrUnsupportedMediaType = errors.New("formato de patch não suportado")
+)
+
+// Document é a representação JSON editável de um recurso. Só os campos
+// presentes no documento original podem ser alterados pelo patch.
+type Document map[string]any
+
+// MergePatch aplica um JSON Merge Patch (RFC 7396)
+func MergePatch(doc Document, patch []byte) (Document, error) {
+	var changes any
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, ErrInvalidPatch
+	}
+	object, ok := changes.(map[string]any)
+	if !ok {
+		// um patch que não é objeto substitui o documento inteiro, o que
+		// nunca é válido para os nossos recursos
+		return nil, ErrInvalidPatch
+	}
+
+	result := clone(doc)
+	for key, value := range object {
+		if _, allowed := doc[key]; !allowed {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotAllowed, key)
+		}
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = mergeValue(result[key], value)
+	}
+	return result, nil
+}
+
+func mergeValue(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergeValue(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
+
+type operation struct {
+	Op    string           `json:"op"`
+	Path  string           `json:"path"`
+	From  string           `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// JSONPatch aplica um JSON Patch (RFC 6902). Como os recursos são objetos
+// planos, apenas caminhos de um nível (/campo) são aceitos.
+func JSONPatch(doc Document, patch []byte) (Document, error) {
+	var operations []operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, ErrInvalidPatch
+	}
+
+	result := clone(doc)
+	for _, op := range operations {
+		field, err := fieldFromPointer(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		var value any
+		if op.Value != nil {
+			if err := json.Unmarshal(*op.Value, &value); err != nil {
+				return nil, ErrInvalidPatch
+			}
+		}
+
+		switch op.Op {
+		case "add", "replace":
+			if op.Value == nil {
+				return nil, ErrInvalidPatch
+			}
+			if _, exists := result[field]; op.Op == "replace" && !exists {
+				return nil, ErrInvalidPatch
+			}
+			result[field] = value
+		case "remove":
+			if _, exists := result[field]; !exists {
+				return nil, ErrInvalidPatch
+			}
+			delete(result, field)
+		case "test":
+			if op.Value == nil || !reflect.DeepEqual(result[field], value) {
+				return nil, ErrTestFailed
+			}
+		case "copy", "move":
+			from, err := fieldFromPointer(doc, op.From)
+			if err != nil {
+				return nil, err
+			}
+			fromValue, exists := result[from]
+			if !exists {
+				return nil, ErrInvalidPatch
+			}
+			if op.Op == "move" {
+				delete(result, from)
+			}
+			result[field] = fromValue
+		default:
+			return nil, ErrInvalidPatch
+		}
+	}
+	return result, nil
+}
+
+// fieldFromPointer converte um JSON Pointer de um nível no nome do campo
+func fieldFromPointer(doc Document, pointer string) (string, error) {
+	if !strings.HasPrefix(pointer, "/") || strings.Count(pointer, "/") != 1 {
+		return "", ErrInvalidPatch
+	}
+	field := strings.NewReplacer("~1", "/", "~0", "~").Replace(pointer[1:])
+	if _, allowed := doc[field]; !allowed {
+		return "", fmt.Errorf("%w: %s", ErrFieldNotAllowed, field)
+	}
+	return field, nil
+}
+
+// Apply escolhe o formato do patch pelo Content-Type. application/json é
+// tratado como merge patch.
+func Apply(doc Document, contentType string, body []byte) (Document, error) {
+	mediaType := strings.TrimSpace(strings.Split(contentType, ";")[0])
+	switch mediaType {
+	case JSONPatchContentType:
+		return JSONPatch(doc, body)
+	case MergePatchContentType, "application/json", "":
+		return MergePatch(doc, body)
+	default:
+		return nil, ErrUnsupportedMediaType
+	}
+}
+
+func clone(doc Document) Document {
+	result := make(Document, len(doc))
+	for key, value := range doc {
+		result[key] = value
+	}
+	return result
+}
+
+// String lê um campo de texto obrigatório do documento
+func (d Document) String(field string) (string, bool) {
+	value, ok := d[field].(string)
+	return value, ok
+}
+
+// Bool lê um campo booleano obrigatório do documento
+func (d Document) Bool(field string) (bool, bool) {
+	value, ok := d[field].(bool)
+	return value, ok
+}
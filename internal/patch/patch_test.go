@@ -0,0 +1,79 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func task() Document {
+	return Document{"text": "Comprar pão", "isCompleted": false}
+}
+
+func TestMergePatch(t *testing.T) {
+	t.Run("Deve alterar apenas os campos enviados", func(t *testing.T) {
+		result, err := MergePatch(task(), []byte(`{"isCompleted": true}`))
+		assert.NoError(t, err)
+		assert.Equal(t, Document{"text": "Comprar pão", "isCompleted": true}, result)
+	})
+
+	t.Run("null remove o campo", func(t *testing.T) {
+		result, err := MergePatch(task(), []byte(`{"text": null}`))
+		assert.NoError(t, err)
+		_, ok := result.String("text")
+		assert.False(t, ok)
+	})
+
+	t.Run("Deve recusar campos fora da lista", func(t *testing.T) {
+		_, err := MergePatch(task(), []byte(`{"ListID": 9}`))
+		assert.ErrorIs(t, err, ErrFieldNotAllowed)
+	})
+
+	t.Run("Deve recusar patch que não é objeto", func(t *testing.T) {
+		_, err := MergePatch(task(), []byte(`["text"]`))
+		assert.ErrorIs(t, err, ErrInvalidPatch)
+	})
+}
+
+func TestJSONPatch(t *testing.T) {
+	t.Run("Deve aplicar as operações em ordem", func(t *testing.T) {
+		result, err := JSONPatch(task(), []byte(`[
+			{"op": "test", "path": "/isCompleted", "value": false},
+			{"op": "replace", "path": "/isCompleted", "value": true},
+			{"op": "replace", "path": "/text", "value": "Comprar leite"}
+		]`))
+		assert.NoError(t, err)
+		assert.Equal(t, Document{"text": "Comprar leite", "isCompleted": true}, result)
+	})
+
+	t.Run("test com valor diferente deve falhar", func(t *testing.T) {
+		_, err := JSONPatch(task(), []byte(`[{"op": "test", "path": "/isCompleted", "value": true}]`))
+		assert.ErrorIs(t, err, ErrTestFailed)
+	})
+
+	t.Run("Deve recusar caminhos fora da lista", func(t *testing.T) {
+		_, err := JSONPatch(task(), []byte(`[{"op": "replace", "path": "/ListID", "value": 2}]`))
+		assert.ErrorIs(t, err, ErrFieldNotAllowed)
+
+		_, err = JSONPatch(task(), []byte(`[{"op": "replace", "path": "/text/0", "value": "x"}]`))
+		assert.ErrorIs(t, err, ErrInvalidPatch)
+	})
+
+	t.Run("Deve recusar operação desconhecida", func(t *testing.T) {
+		_, err := JSONPatch(task(), []byte(`[{"op": "increment", "path": "/text"}]`))
+		assert.ErrorIs(t, err, ErrInvalidPatch)
+	})
+}
+
+func TestApply(t *testing.T) {
+	result, err := Apply(task(), "application/json-patch+json", []byte(`[{"op": "replace", "path": "/text", "value": "x"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, "x", result["text"])
+
+	result, err = Apply(task(), "application/merge-patch+json; charset=utf-8", []byte(`{"text": "y"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "y", result["text"])
+
+	_, err = Apply(task(), "text/plain", []byte(`x`))
+	assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+}
@@ -0,0 +1,122 @@
+// Package ratelimit limita as requisições por cliente com token bucket.
+// Os baldes ficam em um Store: MemoryStore vale para uma réplica só; com
+// várias réplicas, um store compartilhado (ex.: Redis) implementa a mesma
+// interface para que o limite seja o mesmo em todas.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit descreve um balde: Burst tokens de capacidade, repostos a Rate por segundo
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute cria um limite de n requisições por minuto com rajadas de até burst
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// Window é o tempo para encher o balde vazio
+func (l Limit) Window() time.Duration {
+	return seconds(float64(l.Burst) / l.Rate)
+}
+
+// Result é o estado do balde depois de uma tentativa
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter é quanto falta para o próximo token, quando Allowed é false
+	RetryAfter time.Duration
+	// Reset é quanto falta para o balde voltar a ficar cheio
+	Reset time.Duration
+}
+
+// Store guarda os baldes de cada chave
+type Store interface {
+	// Take consome um token do balde da chave, se houver
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// bucket guarda os tokens até updated; os repostos desde então são
+// calculados na próxima tentativa
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full é quando o balde estará cheio de novo
+	full time.Time
+}
+
+// take repõe os tokens do tempo decorrido e tenta consumir um
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.updated = now
+
+	result := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / limit.Rate)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((float64(limit.Burst) - b.tokens) / limit.Rate)
+	return result
+}
+
+// sweepInterval é de quanto em quanto tempo o MemoryStore descarta os baldes cheios
+const sweepInterval = time.Minute
+
+// MemoryStore guarda os baldes na memória do processo
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore cria um store em memória
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket), lastSweep: time.Now(), now: time.Now}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	result := b.take(limit, now)
+	b.full = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweep descarta os baldes que já encheram: recriá-los dá no mesmo
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// seconds converte segundos fracionários em Duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
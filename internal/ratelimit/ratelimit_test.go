@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock controla o tempo visto pelo MemoryStore
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	store := NewMemoryStore()
+	store.now = clock.Now
+	store.lastSweep = clock.now
+	return store, clock
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	// 1 token por segundo, rajada de 3
+	limit := PerMinute(60, 3)
+
+	t.Run("Permite a rajada e depois bloqueia", func(t *testing.T) {
+		store, _ := newTestStore()
+		for remaining := 2; remaining >= 0; remaining-- {
+			result, err := store.Take(ctx, "ip:1", limit)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 3, result.Limit)
+			assert.Equal(t, remaining, result.Remaining)
+		}
+
+		result, err := store.Take(ctx, "ip:1", limit)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, time.Second, result.RetryAfter)
+		assert.Equal(t, 3*time.Second, result.Reset)
+	})
+
+	t.Run("Repõe os tokens com o tempo", func(t *testing.T) {
+		store, clock := newTestStore()
+		for i := 0; i < 3; i++ {
+			store.Take(ctx, "ip:1", limit)
+		}
+
+		clock.now = clock.now.Add(1500 * time.Millisecond)
+		result, _ := store.Take(ctx, "ip:1", limit)
+		assert.True(t, result.Allowed)
+		result, _ = store.Take(ctx, "ip:1", limit)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+		clock.now = clock.now.Add(time.Hour)
+		result, _ = store.Take(ctx, "ip:1", limit)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, result.Remaining, "o balde não passa da capacidade")
+	})
+
+	t.Run("Cada chave tem seu balde", func(t *testing.T) {
+		store, _ := newTestStore()
+		for i := 0; i < 3; i++ {
+			store.Take(ctx, "ip:1", limit)
+		}
+
+		result, _ := store.Take(ctx, "ip:2", limit)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, result.Remaining)
+	})
+
+	t.Run("Descarta os baldes que já encheram", func(t *testing.T) {
+		store, clock := newTestStore()
+		store.Take(ctx, "ip:1", limit)
+		// 1 token por minuto: com dois a menos, leva dois minutos para encher
+		store.Take(ctx, "ip:2", PerMinute(1, 3))
+		store.Take(ctx, "ip:2", PerMinute(1, 3))
+
+		clock.now = clock.now.Add(sweepInterval)
+		store.Take(ctx, "ip:3", limit)
+
+		assert.NotContains(t, store.buckets, "ip:1")
+		assert.Contains(t, store.buckets, "ip:2")
+		assert.Contains(t, store.buckets, "ip:3")
+	})
+}
+
+func TestLimitWindow(t *testing.T) {
+	assert.Equal(t, 10*time.Second, PerMinute(120, 20).Window())
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"listaPro/internal/models"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Reserve grava a chave ainda sem resposta. Se a chave já existe e não
+// expirou, retorna o registro existente sem criar nada.
+func (r *IdempotencyRepository) Reserve(key *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil, nil
+		}
+
+		var existing models.IdempotencyKey
+		err := r.db.Where("scope = ? AND key = ?", key.Scope, key.Key).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// removida entre o insert e a busca; tenta de novo
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if existing.ExpiresAt.After(time.Now()) {
+			return &existing, nil
+		}
+
+		// chave expirada: libera e tenta reservar de novo
+		if err := r.db.Delete(&existing).Error; err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("não foi possível reservar a chave de idempotência")
+}
+
+// Complete salva a resposta da requisição original e o novo vencimento
+func (r *IdempotencyRepository) Complete(key *models.IdempotencyKey) error {
+	return r.db.Model(key).Select("status_code", "content_type", "etag", "body", "expires_at").Updates(key).Error
+}
+
+// Release apaga a reserva, permitindo que o cliente tente de novo
+func (r *IdempotencyRepository) Release(key *models.IdempotencyKey) error {
+	return r.db.Delete(key).Error
+}
+
+// DeleteExpired remove as chaves vencidas
+func (r *IdempotencyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"gorm.io/gorm"
 	"listaPro/internal/models"
 )
@@ -14,8 +15,8 @@ func NewListRepository(db *gorm.DB) *ListRepository {
 }
 
 // Create - Cria uma nova lista
-func (r *ListRepository) Create(list *models.TaskList) error {
-	return r.db.Create(list).Error
+func (r *ListRepository) Create(ctx context.Context, list *models.TaskList) error {
+	return r.db.WithContext(ctx).Create(list).Error
 }
 
 // GetAll - Retorna todas as listas com suas tarefas
@@ -33,13 +34,13 @@ func (r *ListRepository) GetByID(id uint) (*models.TaskList, error) {
 }
 
 // Update - Atualiza o nome de uma lista
-func (r *ListRepository) Update(list *models.TaskList) error {
-	return r.db.Model(list).Update("name", list.Name).Error
+func (r *ListRepository) Update(ctx context.Context, list *models.TaskList) error {
+	return r.db.WithContext(ctx).Model(list).Update("name", list.Name).Error
 }
 
-// Delete - Exclui uma lista por ID (CASCADE via GORM)
-func (r *ListRepository) Delete(id uint) error {
-	return r.db.Delete(&models.TaskList{}, id).Error
+// Delete - Exclui uma lista já carregada, para que o evento do webhook leve os dados dela
+func (r *ListRepository) Delete(ctx context.Context, list *models.TaskList) error {
+	return r.db.WithContext(ctx).Delete(list).Error
 }
 
 // Exists - Verifica se uma lista existe
@@ -48,3 +49,14 @@ func (r *ListRepository) Exists(id uint) (bool, error) {
 	err := r.db.Model(&models.TaskList{}).Where("id = ?", id).Count(&count).Error
 	return count > 0, err
 }
+
+// Find - Busca listas sem carregar as tarefas; sem IDs, retorna todas
+func (r *ListRepository) Find(ctx context.Context, ids ...uint) ([]models.TaskList, error) {
+	var lists []models.TaskList
+	query := r.db.WithContext(ctx).Order("id")
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	err := query.Find(&lists).Error
+	return lists, err
+}
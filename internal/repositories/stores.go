@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"listaPro/internal/models"
+)
+
+// ListStore é a parte do ListRepository usada pelas APIs GraphQL e gRPC.
+// Nos testes, é implementada em memória. Os métodos recebem o contexto da
+// requisição para que as consultas entrem no trace dela.
+type ListStore interface {
+	Find(ctx context.Context, ids ...uint) ([]models.TaskList, error)
+	Create(ctx context.Context, list *models.TaskList) error
+	Update(ctx context.Context, list *models.TaskList) error
+	Delete(ctx context.Context, list *models.TaskList) error
+}
+
+// TaskStore é a parte do TaskRepository usada pelas APIs GraphQL e gRPC
+type TaskStore interface {
+	GetByID(ctx context.Context, id uint) (*models.Task, error)
+	GetAllByLists(ctx context.Context, listIDs []uint) ([]models.Task, error)
+	Search(ctx context.Context, filter TaskFilter) ([]models.Task, error)
+	Create(ctx context.Context, task *models.Task) error
+	Update(ctx context.Context, task *models.Task, fields map[string]interface{}) error
+	Delete(ctx context.Context, task *models.Task) error
+}
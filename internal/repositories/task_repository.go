@@ -1,8 +1,10 @@
 package repositories
 
 import (
+	"context"
 	"gorm.io/gorm"
 	"listaPro/internal/models"
+	"strings"
 )
 
 type TaskRepository struct {
@@ -14,14 +16,14 @@ func NewTaskRepository(db *gorm.DB) *TaskRepository {
 }
 
 // Create cria uma nova tarefa
-func (r *TaskRepository) Create(task *models.Task) error {
-	return r.db.Create(task).Error
+func (r *TaskRepository) Create(ctx context.Context, task *models.Task) error {
+	return r.db.WithContext(ctx).Create(task).Error
 }
 
 // GetByID busca uma tarefa pelo ID
-func (r *TaskRepository) GetByID(id uint) (*models.Task, error) {
+func (r *TaskRepository) GetByID(ctx context.Context, id uint) (*models.Task, error) {
 	var task models.Task
-	err := r.db.First(&task, id).Error
+	err := r.db.WithContext(ctx).First(&task, id).Error
 	return &task, err
 }
 
@@ -32,19 +34,61 @@ func (r *TaskRepository) GetAllByList(listID uint) ([]models.Task, error) {
 	return tasks, err
 }
 
-// Update atualiza uma tarefa
-func (r *TaskRepository) Update(task *models.Task) error {
-	return r.db.Save(task).Error
+// Update grava fields na tarefa já carregada. O GORM copia os valores
+// para task; antes disso, os callbacks ainda veem a linha como estava.
+func (r *TaskRepository) Update(ctx context.Context, task *models.Task, fields map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(task).Updates(fields).Error
 }
 
-// Delete remove uma tarefa
-func (r *TaskRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Task{}, id).Error
+// Delete remove uma tarefa já carregada, para que o evento do webhook leve a lista dela
+func (r *TaskRepository) Delete(ctx context.Context, task *models.Task) error {
+	return r.db.WithContext(ctx).Delete(task).Error
 }
 
 // MarkAsCompleted marca uma tarefa como concluída
-func (r *TaskRepository) MarkAsCompleted(id uint) error {
-	return r.db.Model(&models.Task{}).
-		Where("id = ?", id).
-		Update("is_completed", true).Error
+func (r *TaskRepository) MarkAsCompleted(ctx context.Context, id uint) error {
+	task, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Model(task).Update("is_completed", true).Error
+}
+
+// GetAllByLists busca de uma só vez as tarefas de várias listas
+func (r *TaskRepository) GetAllByLists(ctx context.Context, listIDs []uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db.WithContext(ctx).Where("list_id IN ?", listIDs).Order("id").Find(&tasks).Error
+	return tasks, err
+}
+
+// TaskFilter restringe a busca de tarefas; campos vazios não filtram
+type TaskFilter struct {
+	ListID      *uint
+	IsCompleted *bool
+	Text        string // trecho do texto, sem diferenciar maiúsculas
+}
+
+// Search busca tarefas pelo filtro
+func (r *TaskRepository) Search(ctx context.Context, filter TaskFilter) ([]models.Task, error) {
+	query := r.db.WithContext(ctx).Order("id")
+	if filter.ListID != nil {
+		query = query.Where("list_id = ?", *filter.ListID)
+	}
+	if filter.IsCompleted != nil {
+		query = query.Where("is_completed = ?", *filter.IsCompleted)
+	}
+	if filter.Text != "" {
+		query = query.Where(`text ILIKE ? ESCAPE '\'`, "%"+escapeLike(filter.Text)+"%")
+	}
+
+	var tasks []models.Task
+	err := query.Find(&tasks).Error
+	return tasks, err
+}
+
+// likeEscaper faz %, _ e \ do texto buscado valerem como caracteres comuns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(text string) string {
+	return likeEscaper.Replace(text)
 }
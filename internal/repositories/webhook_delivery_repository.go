@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"listaPro/internal/models"
+	"time"
+)
+
+// WebhookDeliveryRepository é a fila de entregas usada pelo worker de webhooks
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+// ClaimDue reserva as entregas pendentes vencidas. O SKIP LOCKED permite
+// várias réplicas processando a mesma fila.
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, until time.Time) ([]models.WebhookDelivery, error) {
+	var due []models.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, time.Now()).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&due).Error
+		if err != nil || len(due) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(due))
+		for i, delivery := range due {
+			ids[i] = delivery.ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", until).Error
+	})
+	return due, err
+}
+
+// GetWebhook busca o webhook de uma entrega
+func (r *WebhookDeliveryRepository) GetWebhook(ctx context.Context, id uint) (*models.Webhook, error) {
+	var hook models.Webhook
+	err := r.db.WithContext(ctx).First(&hook, id).Error
+	return &hook, err
+}
+
+// SaveDelivery grava o resultado de uma tentativa
+func (r *WebhookDeliveryRepository) SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Save(delivery).Error
+}
+
+// DeadLetter grava a entrega e a copia para as dead letters na mesma transação
+func (r *WebhookDeliveryRepository) DeadLetter(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(delivery).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.WebhookDeadLetter{
+			WebhookID:  delivery.WebhookID,
+			DeliveryID: delivery.ID,
+			EventType:  delivery.EventType,
+			Payload:    delivery.Payload,
+			Attempts:   delivery.Attempts,
+			LastError:  delivery.LastError,
+		}).Error
+	})
+}
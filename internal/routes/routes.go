@@ -0,0 +1,125 @@
+// Package routes registra as rotas da API. Fica separado do main para que
+// os testes consigam montar o mesmo roteador sem subir o servidor.
+package routes
+
+import (
+	"expvar"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"listaPro/internal/graph"
+	"listaPro/internal/handlers"
+	"listaPro/internal/health"
+	"listaPro/internal/metrics"
+	"listaPro/internal/middleware"
+	"listaPro/internal/openapi"
+	"listaPro/internal/repositories"
+	"strings"
+	"time"
+)
+
+// v1DeprecatedAt é quando a v1 passou a ser obsoleta, em favor da /api/v2
+var v1DeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// RateLimits são os middlewares de limite das rotas de leitura e de
+// escrita; os nulos deixam as rotas sem limite
+type RateLimits struct {
+	Read  gin.HandlerFunc
+	Write gin.HandlerFunc
+}
+
+// group cria um grupo com o limite, se houver
+func (l RateLimits) group(api *gin.RouterGroup, limit gin.HandlerFunc) *gin.RouterGroup {
+	if limit == nil {
+		return api
+	}
+	return api.Group("", limit)
+}
+
+// Register adiciona todas as rotas da API ao roteador
+func Register(router *gin.Engine, db *gorm.DB, limits RateLimits) {
+	idempotency := middleware.Idempotency(repositories.NewIdempotencyRepository(db), 24*time.Hour)
+
+	// a v1 continua com os models como estão, avisando que está obsoleta
+	v1 := router.Group("/api", middleware.APIVersion(1), middleware.Deprecated(v1DeprecatedAt, v2Path))
+	registerAPI(v1, db, idempotency, limits)
+
+	v2 := router.Group("/api/v2", middleware.APIVersion(2))
+	registerAPI(v2, db, idempotency, limits)
+
+	//Documentação
+	docs := router.Group("/api")
+	{
+		docs.GET("/openapi.json", openapi.Spec())
+		docs.GET("/docs", openapi.UI())
+		docs.GET("/docs/:file", openapi.Asset())
+	}
+
+	//GraphQL, com o limite de escrita por aceitar mutations
+	resolver := graph.NewResolver(repositories.NewListRepository(db), repositories.NewTaskRepository(db))
+	limits.group(&router.RouterGroup, limits.Write).POST("/graphql", graph.Handler(graph.NewSchema(resolver)))
+}
+
+// RegisterProbes adiciona as rotas das probes do Kubernetes e de versão,
+// fora do versionamento da API
+func RegisterProbes(router *gin.Engine, checker *health.Checker) {
+	router.GET("/healthz", health.Live())
+	router.GET("/readyz", health.Ready(checker))
+	router.GET("/version", health.Version())
+}
+
+// RegisterAdmin adiciona as rotas da porta interna (ADMIN_PORT): métricas e
+// expvar, que mostram a linha de comando e a memória do processo
+func RegisterAdmin(router *gin.Engine) {
+	router.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+	router.GET("/metrics", metrics.Handler())
+}
+
+// registerAPI adiciona as rotas REST ao grupo; v1 e v2 usam os mesmos
+// handlers e só mudam o formato da resposta
+func registerAPI(api *gin.RouterGroup, db *gorm.DB, idempotency gin.HandlerFunc, limits RateLimits) {
+	read := limits.group(api, limits.Read)
+	write := limits.group(api, limits.Write)
+
+	//listas
+	read.GET("/lists", handlers.GetAllLists(db))
+	read.GET("/lists/:id", handlers.GetList(db))
+	write.POST("/lists", idempotency, handlers.CreateList(db))
+	write.PUT("/lists/:id", handlers.UpdateList(db))
+	write.PATCH("/lists/:id", handlers.PatchList(db))
+	write.DELETE("/lists/:id", handlers.DeleteList(db))
+
+	//Tasks
+	read.GET("/lists/:id/tasks", handlers.GetTasksByList(db))
+	write.POST("/lists/:id/tasks", idempotency, handlers.CreateTask(db))
+	read.GET("/tasks/:id", handlers.GetTask(db))
+	write.PUT("/tasks/:id", handlers.UpdateTask(db))
+	write.PATCH("/tasks/:id", handlers.PatchTask(db))
+	write.DELETE("/tasks/:id", handlers.DeleteTask(db))
+
+	//Markdown
+	read.GET("/lists/:id/markdown", handlers.ExportListMarkdown(db))
+	write.POST("/lists/markdown", handlers.ImportMarkdown(db))
+	write.POST("/lists/:id/markdown", handlers.ImportMarkdownIntoList(db))
+
+	//Importação
+	write.POST("/import/:source", handlers.ImportFile(db))
+
+	//Eventos em tempo real
+	read.GET("/events", handlers.StreamEvents())
+
+	//Sincronização offline
+	read.GET("/sync", handlers.GetChanges(db))
+	write.POST("/sync", handlers.ApplyChanges(db))
+
+	//Webhooks
+	read.GET("/webhooks", handlers.GetWebhooks(db))
+	write.POST("/webhooks", handlers.CreateWebhook(db))
+	write.DELETE("/webhooks/:id", handlers.DeleteWebhook(db))
+	read.GET("/webhooks/:id/deliveries", handlers.GetWebhookDeliveries(db))
+	read.GET("/webhooks/:id/dead-letters", handlers.GetWebhookDeadLetters(db))
+}
+
+// v2Path aponta a rota equivalente na v2 (/api/lists -> /api/v2/lists)
+func v2Path(path string) string {
+	return strings.Replace(path, "/api/", "/api/v2/", 1)
+}
@@ -0,0 +1,111 @@
+package routes
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"listaPro/internal/health"
+	"listaPro/internal/openapi"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+func TestRoutesMatchOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Register(router, nil, RateLimits{})
+	RegisterProbes(router, health.NewChecker())
+
+	var registered []openapi.Operation
+	for _, route := range router.Routes() {
+		registered = append(registered, openapi.Operation{Method: route.Method, Path: route.Path})
+	}
+	sort.Slice(registered, func(i, j int) bool {
+		if registered[i].Path == registered[j].Path {
+			return registered[i].Method < registered[j].Method
+		}
+		return registered[i].Path < registered[j].Path
+	})
+
+	documented, err := openapi.Operations()
+	assert.NoError(t, err)
+
+	t.Run("Toda rota registrada está no documento", func(t *testing.T) {
+		for _, op := range registered {
+			assert.Contains(t, documented, op, "rota sem documentação: %s %s", op.Method, op.Path)
+		}
+	})
+
+	t.Run("Toda operação documentada está registrada", func(t *testing.T) {
+		for _, op := range documented {
+			assert.Contains(t, registered, op, "operação documentada sem rota: %s %s", op.Method, op.Path)
+		}
+	})
+}
+
+func TestServeOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Register(router, nil, RateLimits{})
+
+	t.Run("Deve servir o documento", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/openapi.json", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var doc map[string]any
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, "3.1.0", doc["openapi"])
+	})
+
+	t.Run("Deve servir o Swagger UI", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/docs", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "/api/openapi.json")
+	})
+
+	t.Run("Os arquivos do Swagger UI vêm do próprio binário", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/docs", nil)
+		router.ServeHTTP(w, req)
+		assert.NotContains(t, w.Body.String(), "https://")
+
+		for _, file := range []string{"swagger-ui.css", "swagger-ui-bundle.js"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/docs/"+file, nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code, file)
+			assert.NotEmpty(t, w.Body.Bytes(), file)
+		}
+	})
+}
+
+func TestAPIVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	Register(router, nil, RateLimits{})
+
+	t.Run("A v1 avisa que está obsoleta", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/tasks/abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "@1792368000", w.Header().Get("Deprecation"))
+		assert.Equal(t, `</api/v2/tasks/abc>; rel="successor-version"`, w.Header().Get("Link"))
+	})
+
+	t.Run("A v2 não tem aviso", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/api/v2/tasks/abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Empty(t, w.Header().Get("Deprecation"))
+		assert.Empty(t, w.Header().Get("Link"))
+	})
+}
@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "tracing:span"
+
+// Plugin é o plugin do GORM que cria um span por comando SQL, filho do
+// span da requisição quando o handler usa db.WithContext. Comandos sem span
+// pai (migrations, polling do worker de webhooks, coletor de métricas) não
+// são rastreados, para não abrir um trace raiz a cada execução.
+type Plugin struct{}
+
+func (Plugin) Name() string {
+	return "tracing"
+}
+
+// Initialize registra os callbacks em volta de todos os outros
+func (Plugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("*").Register("tracing:before_create", start("create")),
+		callback.Create().After("*").Register("tracing:after_create", end),
+		callback.Query().Before("*").Register("tracing:before_query", start("query")),
+		callback.Query().After("*").Register("tracing:after_query", end),
+		callback.Update().Before("*").Register("tracing:before_update", start("update")),
+		callback.Update().After("*").Register("tracing:after_update", end),
+		callback.Delete().Before("*").Register("tracing:before_delete", start("delete")),
+		callback.Delete().After("*").Register("tracing:after_delete", end),
+		callback.Row().Before("*").Register("tracing:before_row", start("row")),
+		callback.Row().After("*").Register("tracing:after_row", end),
+		callback.Raw().Before("*").Register("tracing:before_raw", start("raw")),
+		callback.Raw().After("*").Register("tracing:after_raw", end),
+	)
+}
+
+func start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if !trace.SpanContextFromContext(db.Statement.Context).IsValid() {
+			return
+		}
+		ctx, span := otel.Tracer(instrumentationName).Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system.name", "postgresql"),
+				attribute.String("db.operation.name", operation),
+			),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(spanKey, statementSpan{span, operation})
+	}
+}
+
+type statementSpan struct {
+	trace.Span
+	operation string
+}
+
+func end(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span := value.(statementSpan)
+	defer span.End()
+
+	if table := db.Statement.Table; table != "" {
+		span.SetName("gorm." + span.operation + " " + table)
+		span.SetAttributes(attribute.String("db.collection.name", table))
+	}
+	// o SQL fica com os placeholders, sem os valores
+	span.SetAttributes(
+		attribute.String("db.query.text", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
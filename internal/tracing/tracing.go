@@ -0,0 +1,80 @@
+// Package tracing configura o OpenTelemetry: um span por requisição no Gin
+// e um span por comando SQL no GORM, propagando o cabeçalho traceparent
+// (W3C Trace Context).
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"listaPro/internal/buildinfo"
+	"listaPro/internal/config"
+	"net/http"
+)
+
+// instrumentationName identifica os spans criados pela aplicação
+const instrumentationName = "listaPro"
+
+// Setup instala o TracerProvider global conforme a configuração. A função
+// devolvida envia os spans pendentes e deve ser chamada no desligamento.
+func Setup(ctx context.Context, cfg config.TracingConfig, env string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch cfg.Exporter {
+	case "otlp":
+		exporter, err = otlptracegrpc.New(ctx)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		// sem exporter, os cabeçalhos traceparent continuam sendo repassados
+		return func(context.Context) error { return nil }, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
+	}
+
+	provider := NewProvider(exporter, cfg.ServiceName, env, cfg.SampleRatio)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewProvider cria o TracerProvider com o exporter informado. Nos testes,
+// use tracetest.NewInMemoryExporter.
+func NewProvider(exporter sdktrace.SpanExporter, serviceName, env string, sampleRatio float64) *sdktrace.TracerProvider {
+	res := resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", buildinfo.Get().Commit),
+		attribute.String("deployment.environment.name", env),
+	)
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// respeita a decisão de amostragem de quem chamou
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+}
+
+// untraced são as rotas chamadas o tempo todo pela infraestrutura
+var untraced = map[string]bool{"/healthz": true, "/readyz": true}
+
+// Middleware cria um span por requisição, continuando o trace do cliente
+// quando vier o cabeçalho traceparent
+func Middleware(serviceName string) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return !untraced[r.URL.Path]
+	}))
+}
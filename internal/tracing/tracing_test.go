@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"listaPro/internal/models"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := NewProvider(exporter, "listapro-test", "dev", 1)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	// DryRun monta o SQL sem executar, mas passa pelos callbacks
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{
+		DisableAutomaticPing: true, DryRun: true, SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(Plugin{}))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware("listapro-test"))
+	router.GET("/api/lists/:id/tasks", func(c *gin.Context) {
+		var tasks []models.Task
+		db.WithContext(c.Request.Context()).Where("list_id = ?", c.Param("id")).Find(&tasks)
+		c.Status(http.StatusOK)
+	})
+	router.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(path, traceparent string) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if traceparent != "" {
+			req.Header.Set("traceparent", traceparent)
+		}
+		router.ServeHTTP(w, req)
+		require.NoError(t, provider.ForceFlush(context.Background()))
+	}
+
+	t.Run("SQL vira um span filho da requisição", func(t *testing.T) {
+		exporter.Reset()
+		get("/api/lists/1/tasks", "")
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		query, request := spans[0], spans[1]
+		assert.Equal(t, "/api/lists/:id/tasks", request.Name)
+		assert.Equal(t, "gorm.query tasks", query.Name)
+		assert.Equal(t, request.SpanContext.SpanID(), query.Parent.SpanID())
+		assert.Equal(t, request.SpanContext.TraceID(), query.SpanContext.TraceID())
+
+		attrs := map[string]string{}
+		for _, attr := range query.Attributes {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+		assert.Equal(t, "postgresql", attrs["db.system.name"])
+		assert.Contains(t, attrs["db.query.text"], "list_id = $1")
+	})
+
+	t.Run("Continua o trace do traceparent", func(t *testing.T) {
+		exporter.Reset()
+		get("/api/lists/1/tasks", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		spans := exporter.GetSpans()
+		require.NotEmpty(t, spans)
+		for _, span := range spans {
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+		}
+	})
+
+	t.Run("SQL fora de uma requisição não abre trace", func(t *testing.T) {
+		exporter.Reset()
+		var lists []models.TaskList
+		db.WithContext(context.Background()).Find(&lists)
+		require.NoError(t, provider.ForceFlush(context.Background()))
+		assert.Empty(t, exporter.GetSpans())
+	})
+
+	t.Run("Probes não geram spans", func(t *testing.T) {
+		exporter.Reset()
+		get("/healthz", "")
+		assert.Empty(t, exporter.GetSpans())
+	})
+}
@@ -0,0 +1,141 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"listaPro/internal/events"
+	"listaPro/internal/models"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Register instala callbacks no GORM que criam as entregas de webhook na
+// mesma transação em que a lista ou tarefa é gravada. Uma alteração
+// confirmada sempre deixa suas entregas na fila, mesmo que o processo caia
+// logo depois do commit; o worker só precisa ler a tabela. Os callbacks
+// rodam antes do commit, e uma falha ao enfileirar desfaz a gravação.
+func Register(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").Register("webhooks:create", record("created")); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").Register("webhooks:update", record("updated")); err != nil {
+		return err
+	}
+	return db.Callback().Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").Register("webhooks:delete", record("deleted"))
+}
+
+var (
+	listType = reflect.TypeOf(models.TaskList{})
+	taskType = reflect.TypeOf(models.Task{})
+)
+
+func record(action string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		stmt := db.Statement
+		if db.Error != nil || stmt.Schema == nil || stmt.RowsAffected == 0 {
+			return
+		}
+		if stmt.Schema.ModelType != listType && stmt.Schema.ModelType != taskType {
+			return
+		}
+
+		var values []any
+		switch value := reflect.Indirect(stmt.ReflectValue); value.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < value.Len(); i++ {
+				values = append(values, reflect.Indirect(value.Index(i)).Interface())
+			}
+		default:
+			values = append(values, value.Interface())
+		}
+
+		// NewDB mantém a conexão da transação e o contexto da requisição
+		tx := db.Session(&gorm.Session{NewDB: true})
+		for _, value := range values {
+			event, err := eventFor(action, value)
+			if err == nil {
+				err = Enqueue(tx, event)
+			}
+			if err != nil {
+				db.AddError(fmt.Errorf("webhooks: %w", err))
+				return
+			}
+		}
+	}
+}
+
+// eventFor monta o evento da alteração com os mesmos dados publicados
+// pelos handlers. O ID do evento é o change_seq da alteração.
+func eventFor(action string, value any) (events.Event, error) {
+	event := events.Event{Time: time.Now().UTC()}
+	switch v := value.(type) {
+	case models.TaskList:
+		if v.ID == 0 {
+			return event, fmt.Errorf("lista sem ID; carregue o registro antes de alterá-lo")
+		}
+		event.Type, event.ListID, event.ID = "list."+action, v.ID, uint64(v.ChangeSeq)
+		if action != "deleted" {
+			event.Data = v
+		}
+	case models.Task:
+		if v.ID == 0 || v.ListID == 0 {
+			return event, fmt.Errorf("tarefa sem ID ou lista; carregue o registro antes de alterá-lo")
+		}
+		event.Type, event.ListID, event.ID = "task."+action, v.ListID, uint64(v.ChangeSeq)
+		event.Data = v
+		if action == "deleted" {
+			event.Data = map[string]uint{"ID": v.ID}
+		}
+	}
+	return event, nil
+}
+
+// Enqueue cria, com tx, uma entrega pendente para cada webhook interessado no evento
+func Enqueue(tx *gorm.DB, event events.Event) error {
+	var hooks []models.Webhook
+	err := tx.Where("active = ?", true).
+		Where("list_id IS NULL OR list_id = ?", event.ListID).
+		Find(&hooks).Error
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	for _, hook := range hooks {
+		if !Subscribed(hook, event.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return err
+			}
+		}
+		delivery := models.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventType:     event.Type,
+			Payload:       string(payload),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: event.Time,
+		}
+		if err := tx.Create(&delivery).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Subscribed indica se o webhook quer receber o tipo de evento
+func Subscribed(hook models.Webhook, eventType string) bool {
+	if strings.TrimSpace(hook.Events) == "" {
+		return true
+	}
+	for _, wanted := range strings.Split(hook.Events, ",") {
+		if strings.TrimSpace(wanted) == eventType {
+			return true
+		}
+	}
+	return false
+}
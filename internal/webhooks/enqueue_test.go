@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"listaPro/internal/models"
+)
+
+func TestRegisterOrder(t *testing.T) {
+	// DryRun com a transação padrão ligada, para que os callbacks de
+	// begin e commit façam parte da cadeia
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DisableAutomaticPing: true, DryRun: true, Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, Register(db))
+
+	var order []string
+	probe := func(name string) func(*gorm.DB) {
+		return func(*gorm.DB) { order = append(order, name) }
+	}
+
+	t.Run("Enfileira antes do commit da gravação", func(t *testing.T) {
+		processors := map[string]interface {
+			Replace(string, func(*gorm.DB)) error
+		}{
+			"create": db.Callback().Create(),
+			"update": db.Callback().Update(),
+			"delete": db.Callback().Delete(),
+		}
+		for op, processor := range processors {
+			// Replace troca a função e mantém a posição do callback
+			for _, name := range []string{"gorm:begin_transaction", "gorm:" + op, "webhooks:" + op, "gorm:commit_or_rollback_transaction"} {
+				require.NoError(t, processor.Replace(name, probe(name)))
+			}
+		}
+
+		list := models.TaskList{Model: gorm.Model{ID: 1}, Name: "Mercado"}
+		run := map[string]func(){
+			"create": func() { db.Create(&list) },
+			"update": func() { db.Model(&list).Update("name", "Feira") },
+			"delete": func() { db.Delete(&list) },
+		}
+		for op, fn := range run {
+			order = nil
+			fn()
+			assert.Equal(t, []string{
+				"gorm:begin_transaction",
+				"gorm:" + op,
+				"webhooks:" + op,
+				"gorm:commit_or_rollback_transaction",
+			}, order, op)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress é devolvido quando o destino de um webhook resolve
+// para um endereço interno (loopback, rede privada, link-local etc.)
+var ErrForbiddenAddress = errors.New("webhooks: destino com endereço interno")
+
+// NewClient monta o cliente HTTP das entregas. A checagem do endereço é
+// feita na conexão, depois da resolução do DNS, para que um nome que
+// resolve para a rede interna (ou passa a resolver, no DNS rebinding) não
+// seja alcançado. Redirecionamentos não são seguidos: a resposta 3xx
+// falha a entrega.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, KeepAlive: 30 * time.Second, Control: dialControl}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// CheckURL valida a URL de um webhook no cadastro: esquema http ou https,
+// host presente e, quando o host é um IP literal ou localhost, um endereço
+// público. Nomes são conferidos de novo a cada conexão por NewClient.
+func CheckURL(raw string) error {
+	target, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return fmt.Errorf("webhooks: URL %q sem esquema http(s) ou host", raw)
+	}
+	host := strings.TrimSuffix(strings.ToLower(target.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !allowed(addr) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+func dialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !allowed(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+func allowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() && !addr.IsUnspecified()
+}
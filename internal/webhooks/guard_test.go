@@ -0,0 +1,81 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"listaPro/internal/models"
+)
+
+func TestCheckURL(t *testing.T) {
+	t.Run("Aceita destinos públicos", func(t *testing.T) {
+		for _, raw := range []string{"https://exemplo.com/hook", "http://93.184.216.34:8080/hook", "https://[2606:4700::1111]/hook"} {
+			assert.NoError(t, CheckURL(raw), raw)
+		}
+	})
+
+	t.Run("Rejeita destinos internos", func(t *testing.T) {
+		for _, raw := range []string{
+			"http://localhost/hook",
+			"http://api.localhost/hook",
+			"http://127.0.0.1:8080/hook",
+			"http://10.1.2.3/hook",
+			"http://192.168.0.10/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://0.0.0.0/hook",
+			"http://[::1]/hook",
+			"http://[::ffff:127.0.0.1]/hook",
+			"http://[fe80::1]/hook",
+		} {
+			assert.ErrorIs(t, CheckURL(raw), ErrForbiddenAddress, raw)
+		}
+	})
+
+	t.Run("Rejeita URL sem esquema http(s) ou host", func(t *testing.T) {
+		for _, raw := range []string{"exemplo.com/hook", "ftp://exemplo.com/hook", "https:///hook"} {
+			assert.Error(t, CheckURL(raw), raw)
+		}
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("Recusa conectar em endereço interno", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("o receiver local não deveria ser alcançado")
+		}))
+		defer receiver.Close()
+
+		worker := NewWorker(nil)
+		_, err := worker.Send(context.Background(), models.Webhook{URL: receiver.URL}, &models.WebhookDelivery{Payload: "{}"})
+		assert.ErrorIs(t, err, ErrForbiddenAddress)
+	})
+
+	t.Run("Não segue redirecionamentos", func(t *testing.T) {
+		var followed bool
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/interno" {
+				followed = true
+				return
+			}
+			http.Redirect(w, r, "/interno", http.StatusTemporaryRedirect)
+		}))
+		defer receiver.Close()
+
+		// o transporte do teste alcança o receiver local; a política de
+		// redirecionamento é a de NewClient
+		client := NewClient(time.Second)
+		client.Transport = receiver.Client().Transport
+		worker := NewWorker(nil)
+		worker.Client = client
+
+		status, err := worker.Send(context.Background(), models.Webhook{URL: receiver.URL + "/hook"}, &models.WebhookDelivery{Payload: "{}"})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusTemporaryRedirect, status)
+		assert.False(t, followed)
+	})
+}
@@ -0,0 +1,36 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader contém a assinatura HMAC-SHA256 do corpo, no formato sha256=<hex>
+const SignatureHeader = "X-ListaPro-Signature"
+
+// Sign calcula a assinatura do corpo com o segredo do webhook
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify confere a assinatura recebida em tempo constante
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// NewSecret gera um segredo aleatório para um novo webhook
+func NewSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
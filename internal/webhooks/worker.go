@@ -0,0 +1,192 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"listaPro/internal/logging"
+	"listaPro/internal/models"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Store guarda a fila de entregas. A implementação com GORM fica em
+// repositories; nos testes, em memória.
+type Store interface {
+	// ClaimDue reserva até limit entregas pendentes vencidas, adiando a
+	// próxima tentativa delas para until
+	ClaimDue(ctx context.Context, limit int, until time.Time) ([]models.WebhookDelivery, error)
+	GetWebhook(ctx context.Context, id uint) (*models.Webhook, error)
+	SaveDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	// DeadLetter grava a entrega morta e a copia para as dead letters
+	DeadLetter(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+// Worker envia em segundo plano as entregas criadas por Register, com
+// backoff exponencial. Entregas que esgotam as tentativas vão para a
+// tabela de dead letters.
+type Worker struct {
+	store Store
+
+	Client       *http.Client
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+	// Lease é a folga da reserva, somada ao pior caso do lote: BatchSize
+	// envios que esgotam o Client.Timeout. Uma entrega não pode vencer de
+	// novo, e ir para outra réplica, enquanto o lote ainda a espera.
+	Lease time.Duration
+
+	running atomic.Bool
+}
+
+func NewWorker(store Store) *Worker {
+	return &Worker{
+		store:        store,
+		Client:       NewClient(10 * time.Second),
+		MaxAttempts:  8,
+		BaseBackoff:  10 * time.Second,
+		MaxBackoff:   6 * time.Hour,
+		PollInterval: 2 * time.Second,
+		BatchSize:    20,
+		Lease:        time.Minute,
+	}
+}
+
+// Run processa a fila a cada PollInterval até o contexto ser cancelado
+func (w *Worker) Run(ctx context.Context) {
+	w.running.Store(true)
+	defer w.running.Store(false)
+
+	ticker := time.NewTicker(w.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.ProcessDue(ctx); err != nil {
+				slog.Error("webhooks: erro ao processar entregas", logging.Err(err))
+			}
+		}
+	}
+}
+
+// Running indica se Run está em execução
+func (w *Worker) Running() bool {
+	return w.running.Load()
+}
+
+// ProcessDue reserva e envia as entregas pendentes cujo horário já chegou
+func (w *Worker) ProcessDue(ctx context.Context) error {
+	due, err := w.store.ClaimDue(ctx, w.BatchSize, time.Now().Add(w.lease()))
+	if err != nil {
+		return err
+	}
+
+	for i := range due {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := w.attempt(ctx, &due[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lease é por quanto tempo o lote fica reservado para esta réplica
+func (w *Worker) lease() time.Duration {
+	return time.Duration(w.BatchSize)*w.Client.Timeout + w.Lease
+}
+
+func (w *Worker) attempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	hook, err := w.store.GetWebhook(ctx, delivery.WebhookID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		// falha ao ler o webhook: a entrega fica pendente e volta a vencer
+		// quando a reserva expirar
+		return err
+	}
+	if err != nil || !hook.Active {
+		// webhook removido ou desativado: a entrega vai direto para dead letter
+		delivery.LastError = "webhook removido ou desativado"
+		return w.kill(ctx, delivery)
+	}
+
+	status, err := w.Send(ctx, *hook, delivery)
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+
+	if err == nil {
+		now := time.Now()
+		delivery.Status = models.DeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		return w.store.SaveDelivery(ctx, delivery)
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= w.MaxAttempts {
+		return w.kill(ctx, delivery)
+	}
+
+	delivery.NextAttemptAt = time.Now().Add(w.Backoff(delivery.Attempts))
+	return w.store.SaveDelivery(ctx, delivery)
+}
+
+func (w *Worker) kill(ctx context.Context, delivery *models.WebhookDelivery) error {
+	delivery.Status = models.DeliveryDead
+	return w.store.DeadLetter(ctx, delivery)
+}
+
+// Send faz o POST assinado da entrega. Qualquer resposta fora de 2xx é erro.
+func (w *Worker) Send(ctx context.Context, hook models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "listaPro-webhooks")
+	req.Header.Set("X-ListaPro-Event", delivery.EventType)
+	req.Header.Set("X-ListaPro-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("resposta %d do destino", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Backoff retorna a espera antes da próxima tentativa: base * 2^(tentativas-1), limitado a MaxBackoff
+func (w *Worker) Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	wait := w.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= w.MaxBackoff {
+			return w.MaxBackoff
+		}
+	}
+	return wait
+}
@@ -0,0 +1,339 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"listaPro/internal/models"
+)
+
+// memoryStore é a fila de entregas em memória para os testes
+type memoryStore struct {
+	mu          sync.Mutex
+	hooks       map[uint]models.Webhook
+	deliveries  map[uint]models.WebhookDelivery
+	deadLetters []models.WebhookDeadLetter
+	// hookErr simula uma falha do banco ao ler o webhook
+	hookErr error
+}
+
+func newMemoryStore(hooks ...models.Webhook) *memoryStore {
+	store := &memoryStore{hooks: make(map[uint]models.Webhook), deliveries: make(map[uint]models.WebhookDelivery)}
+	for _, hook := range hooks {
+		store.hooks[hook.ID] = hook
+	}
+	return store
+}
+
+func (s *memoryStore) add(delivery models.WebhookDelivery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delivery.ID = uint(len(s.deliveries) + 1)
+	s.deliveries[delivery.ID] = delivery
+}
+
+func (s *memoryStore) get(id uint) models.WebhookDelivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deliveries[id]
+}
+
+func (s *memoryStore) ClaimDue(_ context.Context, limit int, until time.Time) ([]models.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []models.WebhookDelivery
+	for id, delivery := range s.deliveries {
+		if delivery.Status == models.DeliveryPending && !delivery.NextAttemptAt.After(time.Now()) && len(due) < limit {
+			due = append(due, delivery)
+			delivery.NextAttemptAt = until
+			s.deliveries[id] = delivery
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	return due, nil
+}
+
+func (s *memoryStore) GetWebhook(_ context.Context, id uint) (*models.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hookErr != nil {
+		return nil, s.hookErr
+	}
+	hook, ok := s.hooks[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &hook, nil
+}
+
+func (s *memoryStore) SaveDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (s *memoryStore) DeadLetter(_ context.Context, delivery *models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries[delivery.ID] = *delivery
+	s.deadLetters = append(s.deadLetters, models.WebhookDeadLetter{
+		WebhookID:  delivery.WebhookID,
+		DeliveryID: delivery.ID,
+		EventType:  delivery.EventType,
+		Payload:    delivery.Payload,
+		Attempts:   delivery.Attempts,
+		LastError:  delivery.LastError,
+	})
+	return nil
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"type":"task.updated"}`)
+	signature := Sign("segredo", body)
+
+	assert.True(t, Verify("segredo", body, signature))
+	assert.False(t, Verify("outro", body, signature))
+	assert.False(t, Verify("segredo", []byte(`{}`), signature))
+	assert.False(t, Verify("segredo", body, "md5=abc"))
+}
+
+func TestBackoff(t *testing.T) {
+	worker := &Worker{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	assert.Equal(t, time.Second, worker.Backoff(1))
+	assert.Equal(t, 2*time.Second, worker.Backoff(2))
+	assert.Equal(t, 8*time.Second, worker.Backoff(4))
+	assert.Equal(t, 10*time.Second, worker.Backoff(5))
+	assert.Equal(t, 10*time.Second, worker.Backoff(50))
+}
+
+func TestLease(t *testing.T) {
+	worker := NewWorker(nil)
+
+	// o lote inteiro cabe na reserva, mesmo com todos os envios no timeout
+	assert.Greater(t, worker.lease(), time.Duration(worker.BatchSize)*worker.Client.Timeout)
+
+	worker.BatchSize = 5
+	worker.Client = &http.Client{Timeout: 3 * time.Second}
+	worker.Lease = time.Second
+	assert.Equal(t, 16*time.Second, worker.lease())
+}
+
+func TestSubscribed(t *testing.T) {
+	assert.True(t, Subscribed(models.Webhook{}, "task.created"))
+	assert.True(t, Subscribed(models.Webhook{Events: "task.created, task.updated"}, "task.updated"))
+	assert.False(t, Subscribed(models.Webhook{Events: "list.deleted"}, "task.updated"))
+}
+
+func TestSend(t *testing.T) {
+	t.Run("Deve enviar o payload assinado", func(t *testing.T) {
+		var received *http.Request
+		var body []byte
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		hook := models.Webhook{URL: receiver.URL, Secret: "segredo"}
+		delivery := &models.WebhookDelivery{Model: gorm.Model{ID: 9}, EventType: "task.updated", Payload: `{"type":"task.updated"}`}
+
+		worker := NewWorker(nil)
+		worker.Client = receiver.Client()
+		status, err := worker.Send(context.Background(), hook, delivery)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+
+		assert.Equal(t, "task.updated", received.Header.Get("X-ListaPro-Event"))
+		assert.Equal(t, "9", received.Header.Get("X-ListaPro-Delivery"))
+		assert.True(t, Verify("segredo", body, received.Header.Get(SignatureHeader)))
+	})
+
+	t.Run("Resposta de erro deve falhar a entrega", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer receiver.Close()
+
+		hook := models.Webhook{URL: receiver.URL, Secret: "segredo"}
+		worker := NewWorker(nil)
+		worker.Client = receiver.Client()
+		status, err := worker.Send(context.Background(), hook, &models.WebhookDelivery{Payload: "{}"})
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusBadGateway, status)
+	})
+}
+
+func TestProcessDue(t *testing.T) {
+	ctx := context.Background()
+
+	// receiver responde com os status da fila, um por requisição
+	receiver := func(statuses ...int) *httptest.Server {
+		var mu sync.Mutex
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			w.WriteHeader(status)
+		}))
+	}
+	// sem backoff, toda entrega com falha volta a vencer na hora; o
+	// cliente padrão alcança o receiver local, que NewClient recusa
+	newTestWorker := func(store Store) *Worker {
+		worker := NewWorker(store)
+		worker.Client = http.DefaultClient
+		worker.MaxAttempts = 3
+		worker.BaseBackoff = 0
+		worker.MaxBackoff = 0
+		return worker
+	}
+	pending := models.WebhookDelivery{WebhookID: 1, EventType: "task.created", Payload: `{"type":"task.created"}`, Status: models.DeliveryPending}
+
+	t.Run("Tenta de novo até esgotar e vai para dead letter", func(t *testing.T) {
+		server := receiver(http.StatusInternalServerError)
+		defer server.Close()
+		store := newMemoryStore(models.Webhook{Model: gorm.Model{ID: 1}, URL: server.URL, Secret: "s", Active: true})
+		store.add(pending)
+		worker := newTestWorker(store)
+
+		for attempt := 1; attempt < 3; attempt++ {
+			require.NoError(t, worker.ProcessDue(ctx))
+			delivery := store.get(1)
+			assert.Equal(t, models.DeliveryPending, delivery.Status)
+			assert.Equal(t, attempt, delivery.Attempts)
+			assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+			assert.Empty(t, store.deadLetters)
+		}
+
+		require.NoError(t, worker.ProcessDue(ctx))
+		delivery := store.get(1)
+		assert.Equal(t, models.DeliveryDead, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		require.Len(t, store.deadLetters, 1)
+		assert.Equal(t, uint(1), store.deadLetters[0].DeliveryID)
+		assert.Equal(t, 3, store.deadLetters[0].Attempts)
+		assert.Contains(t, store.deadLetters[0].LastError, "500")
+
+		// entregas mortas não voltam para a fila
+		require.NoError(t, worker.ProcessDue(ctx))
+		assert.Equal(t, 3, store.get(1).Attempts)
+	})
+
+	t.Run("Entrega depois de uma falha", func(t *testing.T) {
+		server := receiver(http.StatusBadGateway, http.StatusOK)
+		defer server.Close()
+		store := newMemoryStore(models.Webhook{Model: gorm.Model{ID: 1}, URL: server.URL, Secret: "s", Active: true})
+		store.add(pending)
+		worker := newTestWorker(store)
+
+		require.NoError(t, worker.ProcessDue(ctx))
+		require.NoError(t, worker.ProcessDue(ctx))
+
+		delivery := store.get(1)
+		assert.Equal(t, models.DeliveryDelivered, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.NotNil(t, delivery.DeliveredAt)
+		assert.Empty(t, delivery.LastError)
+		assert.Empty(t, store.deadLetters)
+	})
+
+	t.Run("Respeita o backoff entre as tentativas", func(t *testing.T) {
+		server := receiver(http.StatusInternalServerError)
+		defer server.Close()
+		store := newMemoryStore(models.Webhook{Model: gorm.Model{ID: 1}, URL: server.URL, Secret: "s", Active: true})
+		store.add(pending)
+		worker := newTestWorker(store)
+		worker.BaseBackoff = time.Hour
+		worker.MaxBackoff = time.Hour
+
+		require.NoError(t, worker.ProcessDue(ctx))
+		require.NoError(t, worker.ProcessDue(ctx))
+
+		delivery := store.get(1)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), delivery.NextAttemptAt, time.Minute)
+	})
+
+	t.Run("Webhook removido vai direto para dead letter", func(t *testing.T) {
+		store := newMemoryStore()
+		store.add(pending)
+
+		require.NoError(t, newTestWorker(store).ProcessDue(ctx))
+
+		assert.Equal(t, models.DeliveryDead, store.get(1).Status)
+		assert.Equal(t, 0, store.get(1).Attempts)
+		require.Len(t, store.deadLetters, 1)
+		assert.Equal(t, "webhook removido ou desativado", store.deadLetters[0].LastError)
+	})
+
+	t.Run("Webhook desativado vai direto para dead letter", func(t *testing.T) {
+		store := newMemoryStore(models.Webhook{Model: gorm.Model{ID: 1}, URL: "https://exemplo.com/hook", Active: false})
+		store.add(pending)
+
+		require.NoError(t, newTestWorker(store).ProcessDue(ctx))
+
+		assert.Equal(t, models.DeliveryDead, store.get(1).Status)
+		require.Len(t, store.deadLetters, 1)
+	})
+
+	t.Run("Falha ao ler o webhook mantém a entrega pendente", func(t *testing.T) {
+		store := newMemoryStore(models.Webhook{Model: gorm.Model{ID: 1}, URL: "https://exemplo.com/hook", Active: true})
+		store.hookErr = errors.New("conexão recusada")
+		store.add(pending)
+
+		assert.Error(t, newTestWorker(store).ProcessDue(ctx))
+
+		delivery := store.get(1)
+		assert.Equal(t, models.DeliveryPending, delivery.Status)
+		assert.Equal(t, 0, delivery.Attempts)
+		assert.Empty(t, store.deadLetters)
+	})
+}
+
+func TestEventFor(t *testing.T) {
+	list := models.TaskList{Model: gorm.Model{ID: 4}, Name: "Mercado", ChangeSeq: 17}
+	task := models.Task{Model: gorm.Model{ID: 9}, Text: "Leite", ListID: 4, ChangeSeq: 18}
+
+	t.Run("Criação leva o registro", func(t *testing.T) {
+		event, err := eventFor("created", task)
+		require.NoError(t, err)
+		assert.Equal(t, "task.created", event.Type)
+		assert.Equal(t, uint(4), event.ListID)
+		assert.Equal(t, uint64(18), event.ID)
+		assert.Equal(t, task, event.Data)
+	})
+
+	t.Run("Exclusão leva só o que os handlers publicam", func(t *testing.T) {
+		event, err := eventFor("deleted", list)
+		require.NoError(t, err)
+		assert.Equal(t, "list.deleted", event.Type)
+		assert.Equal(t, uint(4), event.ListID)
+		assert.Nil(t, event.Data)
+
+		event, err = eventFor("deleted", task)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]uint{"ID": 9}, event.Data)
+	})
+
+	t.Run("Registro não carregado é erro", func(t *testing.T) {
+		_, err := eventFor("deleted", models.Task{Model: gorm.Model{ID: 9}})
+		assert.Error(t, err)
+		_, err = eventFor("updated", models.TaskList{})
+		assert.Error(t, err)
+	})
+}
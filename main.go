@@ -1,58 +1,105 @@
 package main
 
 import (
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
+	"errors"
+	"fmt"
 	"github.com/joho/godotenv"
 	"gorm.io/gorm"
+	"listaPro/internal/buildinfo"
+	"listaPro/internal/changes"
 	"listaPro/internal/config"
-	"listaPro/internal/handlers"
-	"log"
+	"listaPro/internal/logging"
+	"listaPro/internal/webhooks"
+	"log/slog"
 	"os"
-	"time"
 )
 
-var db *gorm.DB
+const usage = `uso: listapro <comando> [opções]
+
+comandos:
+  serve                        inicia as APIs REST, GraphQL e gRPC (padrão)
+  migrate up|down [N]|status   aplica, reverte ou lista as migrações
+  seed --demo [--force]        cria listas de demonstração
+  export [--list ID] [--format json|markdown] [--output arquivo]
+                               exporta as listas com as tarefas
+  purge --older-than 30d [--dry-run]
+                               remove de vez os registros excluídos há mais tempo
+  version                      mostra a versão e o commit do binário
+`
 
 func main() {
 
 	godotenv.Load()
 
-	db = config.ConnectDB()
-
-	config.Migrate(db)
+	if err := run(os.Args[1:]); err != nil {
+		slog.Error("falha ao executar o comando", logging.Err(err))
+		os.Exit(1)
+	}
+}
 
-	router := gin.Default()
+// run executa o subcomando; sem argumentos, inicia o servidor
+func run(args []string) error {
+	command := "serve"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
 
-	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
+	switch command {
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	case "version", "--version":
+		info := buildinfo.Get()
+		fmt.Printf("listapro %s (commit %s, %s)\n", info.Version, info.Commit, info.GoVersion)
+		return nil
+	case "serve", "migrate", "seed", "export", "purge":
+	default:
+		return fmt.Errorf("comando desconhecido: %s\n\n%s", command, usage)
+	}
 
-	api := router.Group("/api")
-	{
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	logger, err := logging.New(os.Stdout, cfg.Logging.Format, cfg.Logging.Level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
 
-		//listas
-		api.GET("/lists", handlers.GetAllLists(db))
-		api.POST("/lists", handlers.CreateList(db))
-		api.PUT("/lists/:id", handlers.UpdateList(db))
-		api.DELETE("/lists/:id", handlers.DeleteList(db))
+	db, err := openDB(cfg.Database)
+	if err != nil {
+		return err
+	}
 
-		//Tasks
-		api.GET("/lists/:id/tasks", handlers.GetTasksByList(db))
-		api.POST("/lists/:id/tasks", handlers.CreateTask(db))
-		api.PUT("/tasks/:id", handlers.UpdateTask(db))
-		api.DELETE("/tasks/:id", handlers.DeleteTask(db))
+	switch command {
+	case "migrate":
+		return runMigrate(db, args)
+	case "seed":
+		return runSeed(db, args)
+	case "export":
+		return runExport(db, args)
+	case "purge":
+		return runPurge(db, args)
 	}
+	return runServe(db, cfg, args)
+}
 
-	//Inicia Servidor!
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+// openDB conecta ao banco e registra os callbacks de ChangeSeq, que todos
+// os comandos que gravam dados precisam
+func openDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := config.ConnectDB(cfg)
+	if err != nil {
+		return nil, err
 	}
-	log.Fatal(router.Run(":" + port))
+	if err := changes.Register(db); err != nil {
+		return nil, err
+	}
+	if err := webhooks.Register(db); err != nil {
+		return nil, err
+	}
+	return db, nil
 }
+
+// errUsage indica argumentos inválidos; a mensagem já traz o uso correto
+var errUsage = errors.New(usage)
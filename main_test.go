@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAge(t *testing.T) {
+	t.Run("aceita dias", func(t *testing.T) {
+		age, err := parseAge("30d")
+		require.NoError(t, err)
+		assert.Equal(t, 30*24*time.Hour, age)
+	})
+
+	t.Run("aceita durações do Go", func(t *testing.T) {
+		age, err := parseAge("72h")
+		require.NoError(t, err)
+		assert.Equal(t, 72*time.Hour, age)
+	})
+
+	t.Run("recusa valores inválidos", func(t *testing.T) {
+		for _, value := range []string{"", "d", "trinta", "0d", "-5d", "-1h"} {
+			_, err := parseAge(value)
+			assert.Error(t, err, value)
+		}
+	})
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	err := run([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "comando desconhecido: deploy")
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"listaPro/internal/migrations"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// runMigrate executa o subcomando migrate
+func runMigrate(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.New(sqlDB)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("aplicada  %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("nenhuma migração pendente")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return errUsage
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("revertida %04d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSÃO\tNOME\tAPLICADA EM")
+		for _, status := range statuses {
+			appliedAt := "pendente"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	}
+	return errUsage
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"gorm.io/gorm"
+	"listaPro/internal/models"
+	"listaPro/internal/repositories"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// errDryRun desfaz a transação do purge depois de contar os registros
+var errDryRun = errors.New("dry run")
+
+// purgeTasks seleciona as tarefas excluídas antes do corte e as tarefas das
+// listas que serão removidas (a exclusão de uma lista não apaga as tarefas)
+const purgeTasks = `(deleted_at IS NOT NULL AND deleted_at < @cutoff)
+	OR list_id IN (SELECT id FROM task_lists WHERE deleted_at IS NOT NULL AND deleted_at < @cutoff)`
+
+// purgeSteps são executados em ordem, na mesma transação
+var purgeSteps = []struct {
+	name string
+	sql  string
+}{
+	// subtarefas que ficam perdem o pai que será removido
+	{"subtarefas desligadas", `UPDATE tasks SET parent_id = NULL
+		WHERE parent_id IN (SELECT id FROM tasks WHERE ` + purgeTasks + `) AND NOT (` + purgeTasks + `)`},
+	{"tarefas", `DELETE FROM tasks WHERE ` + purgeTasks},
+	{"listas", `DELETE FROM task_lists WHERE deleted_at IS NOT NULL AND deleted_at < @cutoff`},
+	{"entregas de webhook", `DELETE FROM webhook_deliveries
+		WHERE (deleted_at IS NOT NULL AND deleted_at < @cutoff)
+		OR (status IN ('` + models.DeliveryDelivered + `', '` + models.DeliveryDead + `') AND updated_at < @cutoff)
+		OR webhook_id IN (SELECT id FROM webhooks WHERE deleted_at IS NOT NULL AND deleted_at < @cutoff)`},
+	{"dead letters de webhook", `DELETE FROM webhook_dead_letters
+		WHERE created_at < @cutoff
+		OR webhook_id IN (SELECT id FROM webhooks WHERE deleted_at IS NOT NULL AND deleted_at < @cutoff)`},
+	{"webhooks", `DELETE FROM webhooks WHERE deleted_at IS NOT NULL AND deleted_at < @cutoff`},
+}
+
+// runPurge remove de vez os registros excluídos antes de --older-than, o
+// histórico de webhooks e as chaves de idempotência vencidas.
+//
+// Atenção: o sync offline informa exclusões pelos registros excluídos; um
+// cliente com token anterior ao corte não fica sabendo das exclusões
+// removidas e precisa sincronizar do zero.
+func runPurge(db *gorm.DB, args []string) error {
+	flags := flag.NewFlagSet("purge", flag.ContinueOnError)
+	olderThan := flags.String("older-than", "", "idade mínima dos registros, ex.: 30d ou 72h")
+	dryRun := flags.Bool("dry-run", false, "só conta os registros, sem remover")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *olderThan == "" || flags.NArg() > 0 {
+		return errUsage
+	}
+	age, err := parseAge(*olderThan)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-age)
+	counts := make([]int64, len(purgeSteps)+1)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for i, step := range purgeSteps {
+			result := tx.Exec(step.sql, map[string]any{"cutoff": cutoff})
+			if result.Error != nil {
+				return fmt.Errorf("%s: %w", step.name, result.Error)
+			}
+			counts[i] = result.RowsAffected
+		}
+
+		keys, err := repositories.NewIdempotencyRepository(tx).DeleteExpired(now)
+		if err != nil {
+			return fmt.Errorf("chaves de idempotência: %w", err)
+		}
+		counts[len(purgeSteps)] = keys
+
+		if *dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, step := range purgeSteps {
+		fmt.Fprintf(w, "%s\t%d\n", step.name, counts[i])
+	}
+	fmt.Fprintf(w, "chaves de idempotência\t%d\n", counts[len(purgeSteps)])
+	if *dryRun {
+		fmt.Fprintln(w, "(dry run: nada foi removido)")
+	}
+	return w.Flush()
+}
+
+// parseAge aceita as durações de time.ParseDuration e dias com o sufixo "d"
+func parseAge(value string) (time.Duration, error) {
+	var (
+		age time.Duration
+		err error
+	)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		age, err = time.ParseDuration(value)
+	}
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf("duração inválida: %q (use, por exemplo, 30d ou 72h)", value)
+	}
+	return age, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gorm.io/gorm"
+	"listaPro/internal/models"
+)
+
+// demoTask é uma tarefa de demonstração, com subtarefas opcionais
+type demoTask struct {
+	text     string
+	done     bool
+	children []demoTask
+}
+
+var demoLists = []struct {
+	name  string
+	tasks []demoTask
+}{
+	{"Mercado", []demoTask{
+		{text: "Arroz"},
+		{text: "Feijão"},
+		{text: "Leite", done: true},
+		{text: "Café"},
+	}},
+	{"Viagem", []demoTask{
+		{text: "Comprar passagens", done: true},
+		{text: "Reservar hotel"},
+		{text: "Fazer a mala", children: []demoTask{
+			{text: "Roupas"},
+			{text: "Carregador", done: true},
+			{text: "Documentos"},
+		}},
+	}},
+	{"Trabalho", []demoTask{
+		{text: "Revisar pull requests"},
+		{text: "Reunião de planejamento", done: true},
+		{text: "Atualizar documentação"},
+	}},
+}
+
+// runSeed cria listas de demonstração. Se já houver listas, não faz nada
+// a menos que --force seja informado.
+func runSeed(db *gorm.DB, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	demo := flags.Bool("demo", false, "cria as listas de demonstração")
+	force := flags.Bool("force", false, "cria mesmo que já existam listas")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if !*demo || flags.NArg() > 0 {
+		return errUsage
+	}
+
+	var count int64
+	if err := db.Model(&models.TaskList{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 && !*force {
+		fmt.Printf("já existem %d listas; use --force para criar as de demonstração mesmo assim\n", count)
+		return nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, demoList := range demoLists {
+			list := models.TaskList{Name: demoList.name}
+			if err := tx.Create(&list).Error; err != nil {
+				return err
+			}
+			if err := createDemoTasks(tx, list.ID, nil, demoList.tasks); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d listas de demonstração criadas\n", len(demoLists))
+	return nil
+}
+
+func createDemoTasks(tx *gorm.DB, listID uint, parentID *uint, tasks []demoTask) error {
+	for _, demo := range tasks {
+		task := models.Task{Text: demo.text, IsCompleted: demo.done, ListID: listID, ParentID: parentID}
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+		if err := createDemoTasks(tx, listID, &task.ID, demo.children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+	"listaPro/internal/config"
+	"listaPro/internal/events"
+	"listaPro/internal/grpcapi"
+	"listaPro/internal/health"
+	"listaPro/internal/logging"
+	"listaPro/internal/metrics"
+	"listaPro/internal/middleware"
+	"listaPro/internal/migrations"
+	"listaPro/internal/ratelimit"
+	"listaPro/internal/repositories"
+	"listaPro/internal/routes"
+	"listaPro/internal/tracing"
+	"listaPro/internal/webhooks"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// server agrupa o que roda enquanto a aplicação está no ar, para que o
+// desligamento pare tudo na ordem certa
+type server struct {
+	cfg     *config.Config
+	db      *gorm.DB
+	checker *health.Checker
+	http    *http.Server
+	admin   *http.Server
+	grpc    *grpc.Server
+	// addr é o endereço em que o HTTP está escutando
+	addr net.Addr
+
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
+	stopTracing func(context.Context) error
+}
+
+// runServe inicia a API REST/GraphQL e, em outra porta, a API gRPC. Com
+// SIGINT ou SIGTERM (rollout do Kubernetes), para de aceitar conexões e
+// espera as requisições em andamento até o SHUTDOWN_TIMEOUT.
+func runServe(db *gorm.DB, cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return errUsage
+	}
+
+	// com várias réplicas, o advisory lock faz só uma migrar por vez;
+	// MIGRATE_ON_START=false deixa as migrações para um Job separado
+	if cfg.Features.MigrateOnStart {
+		if err := config.Migrate(db); err != nil {
+			return fmt.Errorf("falha ao migrar banco de dados: %w", err)
+		}
+	}
+
+	if err := config.PublishPoolStats(db); err != nil {
+		return err
+	}
+	if err := metrics.RegisterDB(db); err != nil {
+		return err
+	}
+
+	stopTracing, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.Env)
+	if err != nil {
+		return err
+	}
+	if err := db.Use(tracing.Plugin{}); err != nil {
+		return err
+	}
+
+	checker, err := newChecker(db)
+	if err != nil {
+		return err
+	}
+	router, err := newRouter(db, cfg, checker)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	s := &server{cfg: cfg, db: db, checker: checker, stopTracing: stopTracing}
+	serveErr, err := s.start(router)
+	if err != nil {
+		return errors.Join(err, s.shutdown())
+	}
+
+	select {
+	case <-ctx.Done():
+		slog.Info("sinal recebido, encerrando")
+	case err = <-serveErr:
+		slog.Error("servidor parou", logging.Err(err))
+	}
+	// um segundo sinal encerra na hora
+	stop()
+
+	return errors.Join(err, s.shutdown())
+}
+
+func newRouter(db *gorm.DB, cfg *config.Config, checker *health.Checker) (*gin.Engine, error) {
+	// os logs de acesso saem pelo slog (middleware.AccessLog), não pelo
+	// logger de texto do gin
+	if os.Getenv(gin.EnvGinMode) == "" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	// sem isso o gin aceita o X-Forwarded-For de qualquer cliente, e o IP
+	// usado pelo rate limit e pelos logs seria o que o cliente quisesse
+	if err := router.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("TRUSTED_PROXIES: %w", err)
+	}
+	corsConfig, err := cfg.CORS.Build(cfg.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	router.Use(tracing.Middleware(cfg.Tracing.ServiceName), metrics.HTTP())
+	// o CORS substitui o Vary inteiro, então vem antes do Language, que
+	// acrescenta Accept-Language ao que já estiver lá
+	router.Use(middleware.RequestID(), middleware.AccessLog(), cors.New(corsConfig), middleware.Language(), middleware.Errors())
+	router.NoRoute(middleware.NotFound())
+	router.NoMethod(middleware.MethodNotAllowed())
+
+	routes.Register(router, db, rateLimits(cfg.RateLimit))
+	routes.RegisterProbes(router, checker)
+	return router, nil
+}
+
+// newAdminRouter monta o roteador da porta interna, sem os middlewares da
+// API: quem chega aqui é o Prometheus ou alguém dentro do cluster
+func newAdminRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+	routes.RegisterAdmin(router)
+	return router
+}
+
+// rateLimits monta os limites das rotas. O store em memória conta por
+// réplica; com N réplicas atrás do balanceador, o limite efetivo chega a N
+// vezes o configurado até existir um store compartilhado.
+func rateLimits(cfg config.RateLimitConfig) routes.RateLimits {
+	if !cfg.Enabled {
+		return routes.RateLimits{}
+	}
+	store := ratelimit.NewMemoryStore()
+	return routes.RateLimits{
+		Read:  middleware.RateLimit(store, "read", ratelimit.PerMinute(cfg.Read.PerMinute, cfg.Read.Burst)),
+		Write: middleware.RateLimit(store, "write", ratelimit.PerMinute(cfg.Write.PerMinute, cfg.Write.Burst)),
+	}
+}
+
+// newChecker monta as verificações de readiness do banco e das migrações;
+// as dos workers entram em start
+func newChecker(db *gorm.DB) (*health.Checker, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := migrations.New(sqlDB)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := health.NewChecker()
+	checker.Add("database", health.Ping(sqlDB))
+	checker.Add("migrations", health.Migrations(migrator))
+	return checker, nil
+}
+
+// start sobe os workers e os servidores. Falhas ao abrir as portas voltam
+// na hora; erros ao servir chegam depois pelo canal.
+func (s *server) start(handler http.Handler) (<-chan error, error) {
+	serveErr := make(chan error, 3)
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	s.stopWorkers = stopWorkers
+	if s.cfg.Features.WebhookWorker {
+		worker := webhooks.NewWorker(repositories.NewWebhookDeliveryRepository(s.db))
+		if s.checker != nil {
+			s.checker.Add("webhookWorker", health.Running(worker.Running))
+		}
+		s.workers.Add(1)
+		go func() {
+			defer s.workers.Done()
+			worker.Run(workerCtx)
+		}()
+	}
+
+	//gRPC em porta separada
+	if s.cfg.Features.GRPC {
+		listener, err := net.Listen("tcp", ":"+s.cfg.GRPC.Port)
+		if err != nil {
+			return nil, fmt.Errorf("gRPC: %w", err)
+		}
+		s.grpc = grpcapi.NewServer(repositories.NewListRepository(s.db), repositories.NewTaskRepository(s.db))
+		go func() {
+			if err := s.grpc.Serve(listener); err != nil {
+				serveErr <- fmt.Errorf("gRPC: %w", err)
+			}
+		}()
+	}
+
+	// métricas e expvar numa porta interna, fora do ingress
+	adminListener, err := net.Listen("tcp", ":"+s.cfg.HTTP.AdminPort)
+	if err != nil {
+		return nil, fmt.Errorf("admin: %w", err)
+	}
+	s.admin = &http.Server{
+		Handler:           newAdminRouter(),
+		ReadHeaderTimeout: s.cfg.HTTP.ReadHeaderTimeout,
+		ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+	}
+	go func() {
+		if err := s.admin.Serve(adminListener); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("admin: %w", err)
+		}
+	}()
+
+	//Inicia Servidor!
+	listener, err := net.Listen("tcp", ":"+s.cfg.HTTP.Port)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP: %w", err)
+	}
+	s.addr = listener.Addr()
+	s.http = &http.Server{
+		Handler:           handler,
+		ReadTimeout:       s.cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: s.cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      s.cfg.HTTP.WriteTimeout,
+		IdleTimeout:       s.cfg.HTTP.IdleTimeout,
+		ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+	}
+	go func() {
+		slog.Info("servidor HTTP no ar", "addr", s.addr.String())
+		if err := s.http.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("HTTP: %w", err)
+		}
+	}()
+
+	return serveErr, nil
+}
+
+// shutdown para de aceitar conexões e espera as requisições em andamento;
+// só então para os workers e fecha o pool do banco. Tudo dentro do
+// SHUTDOWN_TIMEOUT. Entregas de webhook pendentes ficam no banco e são
+// enviadas por outra réplica ou na próxima subida.
+func (s *server) shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	// SSE e WatchList não terminam sozinhos
+	events.CloseStreams()
+
+	var (
+		errs []error
+		wg   sync.WaitGroup
+	)
+	if s.grpc != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stopGRPC(ctx, s.grpc)
+		}()
+	}
+	if s.http != nil {
+		if err := s.http.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("HTTP: %w", err))
+			s.http.Close()
+		}
+	}
+	// o Prometheus ainda consegue coletar enquanto o HTTP drena
+	if s.admin != nil {
+		if err := s.admin.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("admin: %w", err))
+			s.admin.Close()
+		}
+	}
+	wg.Wait()
+
+	if s.stopWorkers != nil {
+		s.stopWorkers()
+	}
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, errors.New("workers não terminaram no prazo"))
+	}
+
+	if sqlDB, err := s.db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("banco de dados: %w", err))
+		}
+	}
+
+	// envia os spans que ainda estão no buffer
+	if s.stopTracing != nil {
+		if err := s.stopTracing(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("tracing: %w", err))
+		}
+	}
+
+	if len(errs) == 0 {
+		slog.Info("servidor encerrado")
+	}
+	return errors.Join(errs...)
+}
+
+// stopGRPC espera as chamadas em andamento e, no fim do prazo, derruba o resto
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"listaPro/internal/config"
+	"listaPro/internal/health"
+)
+
+// devConfig é a configuração padrão em dev, sem exigir CORS_ALLOW_ORIGINS
+func devConfig() config.Config {
+	cfg := config.Default()
+	cfg.Env = config.EnvDev
+	return cfg
+}
+
+func TestGracefulShutdown(t *testing.T) {
+	// o pool é aberto sem conectar; o teste só confere que ele é fechado
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	cfg := devConfig()
+	cfg.HTTP.Port = "0"
+	cfg.HTTP.AdminPort = "0"
+	cfg.HTTP.ShutdownTimeout = 5 * time.Second
+	cfg.Features.GRPC = false
+	cfg.Features.WebhookWorker = false
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "ok")
+	})
+
+	s := &server{cfg: &cfg, db: db}
+	_, err = s.start(handler)
+	require.NoError(t, err)
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	response := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + s.addr.String())
+		if err != nil {
+			response <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		response <- result{status: resp.StatusCode, body: string(body)}
+	}()
+	<-started
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.shutdown() }()
+
+	t.Run("Recusa conexões novas durante o desligamento", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			_, err := http.Get("http://" + s.addr.String())
+			return err != nil
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Espera a requisição em andamento", func(t *testing.T) {
+		select {
+		case <-stopped:
+			t.Fatal("o desligamento não esperou a requisição")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		got := <-response
+		require.NoError(t, got.err)
+		assert.Equal(t, http.StatusOK, got.status)
+		assert.Equal(t, "ok", got.body)
+		assert.NoError(t, <-stopped)
+	})
+
+	t.Run("Fecha o pool do banco", func(t *testing.T) {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		assert.ErrorContains(t, sqlDB.Ping(), "database is closed")
+	})
+}
+
+func TestNewRouterTrustedProxies(t *testing.T) {
+	clientIP := func(cfg config.Config, remoteAddr string) string {
+		router, err := newRouter(nil, &cfg, health.NewChecker())
+		require.NoError(t, err)
+		router.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/ip", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		router.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	t.Run("Ignora X-Forwarded-For sem proxies confiáveis", func(t *testing.T) {
+		assert.Equal(t, "198.51.100.1", clientIP(devConfig(), "198.51.100.1:4321"))
+	})
+
+	t.Run("Usa X-Forwarded-For vindo de proxy confiável", func(t *testing.T) {
+		cfg := devConfig()
+		cfg.HTTP.TrustedProxies = []string{"10.0.0.0/8"}
+		assert.Equal(t, "203.0.113.7", clientIP(cfg, "10.1.2.3:4321"))
+		assert.Equal(t, "198.51.100.1", clientIP(cfg, "198.51.100.1:4321"))
+	})
+}
+
+func TestAdminRoutes(t *testing.T) {
+	get := func(router *gin.Engine, path string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	cfg := devConfig()
+	public, err := newRouter(nil, &cfg, health.NewChecker())
+	require.NoError(t, err)
+	admin := newAdminRouter()
+
+	for _, path := range []string{"/metrics", "/debug/vars"} {
+		t.Run(path+" só na porta interna", func(t *testing.T) {
+			assert.Equal(t, http.StatusNotFound, get(public, path))
+			assert.Equal(t, http.StatusOK, get(admin, path))
+		})
+	}
+}
+
+func TestNewRouterVary(t *testing.T) {
+	cfg := devConfig()
+	router, err := newRouter(nil, &cfg, health.NewChecker())
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/version", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.ElementsMatch(t, []string{"Origin", "Accept-Language"}, w.Header().Values("Vary"))
+}